@@ -0,0 +1,137 @@
+package ecx
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	//SpeedUnitKB indicates speed expressed in kilobits per second
+	SpeedUnitKB = "KB"
+	//SpeedUnitMB indicates speed expressed in megabits per second
+	SpeedUnitMB = "MB"
+	//SpeedUnitGB indicates speed expressed in gigabits per second
+	SpeedUnitGB = "GB"
+)
+
+const (
+	//Kilobit is bandwidth of one kilobit per second
+	Kilobit Bandwidth = 1000
+	//Megabit is bandwidth of one megabit per second
+	Megabit Bandwidth = 1000 * Kilobit
+	//Gigabit is bandwidth of one gigabit per second
+	Gigabit Bandwidth = 1000 * Megabit
+)
+
+//Bandwidth describes bandwidth in bits per second. Bandwidth values
+//can be compared, added and subtracted using standard operators
+type Bandwidth int64
+
+//NewBandwidth creates bandwidth from a given speed and speed unit,
+//as used by connections, ports and service profile speed bands
+func NewBandwidth(speed int, speedUnit string) (Bandwidth, error) {
+	unit, err := parseSpeedUnit(speedUnit)
+	if err != nil {
+		return 0, err
+	}
+	return Bandwidth(speed) * unit, nil
+}
+
+//ParseBandwidth parses bandwidth from a string with a speed and a unit,
+//i.e. "500MB", "1 GB" or "10Gbps"
+func ParseBandwidth(s string) (Bandwidth, error) {
+	trimmed := strings.TrimSpace(s)
+	i := 0
+	for i < len(trimmed) && (trimmed[i] >= '0' && trimmed[i] <= '9' || trimmed[i] == '.') {
+		i++
+	}
+	if i == 0 {
+		return 0, fmt.Errorf("invalid bandwidth %q: missing speed", s)
+	}
+	speed, err := strconv.ParseFloat(trimmed[:i], 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid bandwidth %q: %s", s, err)
+	}
+	unit, err := parseSpeedUnit(trimmed[i:])
+	if err != nil {
+		return 0, fmt.Errorf("invalid bandwidth %q: %s", s, err)
+	}
+	return Bandwidth(speed * float64(unit)), nil
+}
+
+//Speed converts bandwidth to speed and speed unit pair. Largest unit that expresses
+//bandwidth as a whole number is used, fractions of a kilobit are truncated
+func (b Bandwidth) Speed() (int, string) {
+	switch {
+	case b == 0:
+		return 0, SpeedUnitMB
+	case b%Gigabit == 0:
+		return int(b / Gigabit), SpeedUnitGB
+	case b%Megabit == 0:
+		return int(b / Megabit), SpeedUnitMB
+	default:
+		return int(b / Kilobit), SpeedUnitKB
+	}
+}
+
+//Megabits returns bandwidth in megabits per second
+func (b Bandwidth) Megabits() float64 {
+	return float64(b) / float64(Megabit)
+}
+
+//Gigabits returns bandwidth in gigabits per second
+func (b Bandwidth) Gigabits() float64 {
+	return float64(b) / float64(Gigabit)
+}
+
+//Cmp compares bandwidth with other bandwidth and returns -1, 0 or 1
+//when bandwidth is lower, equal or higher respectively
+func (b Bandwidth) Cmp(other Bandwidth) int {
+	switch {
+	case b < other:
+		return -1
+	case b > other:
+		return 1
+	default:
+		return 0
+	}
+}
+
+//Percent returns what percentage of a given capacity the bandwidth represents
+func (b Bandwidth) Percent(capacity Bandwidth) float64 {
+	if capacity == 0 {
+		return 0
+	}
+	return float64(b) / float64(capacity) * 100
+}
+
+//String returns normalized textual representation of bandwidth, i.e. "500MB" or "10GB"
+func (b Bandwidth) String() string {
+	if b%Megabit != 0 {
+		return strconv.FormatFloat(b.Megabits(), 'f', -1, 64) + SpeedUnitMB
+	}
+	speed, unit := b.Speed()
+	return strconv.Itoa(speed) + unit
+}
+
+//speedBandwidth converts pointers to speed and speed unit to bandwidth
+func speedBandwidth(speed *int, speedUnit *string) (Bandwidth, error) {
+	if speed == nil || speedUnit == nil {
+		return 0, fmt.Errorf("speed and speed unit need to be defined")
+	}
+	return NewBandwidth(*speed, *speedUnit)
+}
+
+func parseSpeedUnit(speedUnit string) (Bandwidth, error) {
+	switch strings.ToUpper(strings.TrimSpace(speedUnit)) {
+	case "K", "KB", "KBPS":
+		return Kilobit, nil
+	case "M", "MB", "MBPS":
+		return Megabit, nil
+	case "G", "GB", "GBPS":
+		return Gigabit, nil
+	default:
+		return 0, fmt.Errorf("unsupported speed unit %q", speedUnit)
+	}
+}
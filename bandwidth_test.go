@@ -0,0 +1,89 @@
+package ecx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBandwidth(t *testing.T) {
+	//given
+	speeds := []int{500, 1, 10, 200}
+	units := []string{"MB", "GB", "Gbps", "KB"}
+	expected := []Bandwidth{500 * Megabit, Gigabit, 10 * Gigabit, 200 * Kilobit}
+	//when
+	output := make([]Bandwidth, len(speeds))
+	for i := range speeds {
+		b, err := NewBandwidth(speeds[i], units[i])
+		assert.Nil(t, err, "NewBandwidth should not return an error")
+		output[i] = b
+	}
+	_, err := NewBandwidth(1, "TB")
+	//then
+	assert.Equal(t, expected, output, "Output matches expected output")
+	assert.NotNil(t, err, "Unsupported unit results in an error")
+}
+
+func TestParseBandwidth(t *testing.T) {
+	//given
+	input := []string{"500MB", "1 GB", "10Gbps", "1.5GB", " 50 mb ", "250Kbps"}
+	expected := []Bandwidth{500 * Megabit, Gigabit, 10 * Gigabit, 1500 * Megabit, 50 * Megabit, 250 * Kilobit}
+	invalid := []string{"", "MB", "100", "100XB", "1..5GB"}
+	//when
+	output := make([]Bandwidth, len(input))
+	for i := range input {
+		b, err := ParseBandwidth(input[i])
+		assert.Nil(t, err, "ParseBandwidth should not return an error")
+		output[i] = b
+	}
+	//then
+	assert.Equal(t, expected, output, "Output matches expected output")
+	for i := range invalid {
+		_, err := ParseBandwidth(invalid[i])
+		assert.NotNil(t, err, "Invalid bandwidth results in an error")
+	}
+}
+
+func TestBandwidth_Speed(t *testing.T) {
+	//given
+	input := []Bandwidth{1000 * Megabit, 1500 * Megabit, 50 * Megabit, 0, 512 * Kilobit, 1500 * Kilobit}
+	expectedSpeeds := []int{1, 1500, 50, 0, 512, 1500}
+	expectedUnits := []string{SpeedUnitGB, SpeedUnitMB, SpeedUnitMB, SpeedUnitMB, SpeedUnitKB, SpeedUnitKB}
+	//when
+	speeds := make([]int, len(input))
+	units := make([]string, len(input))
+	for i := range input {
+		speeds[i], units[i] = input[i].Speed()
+	}
+	//then
+	assert.Equal(t, expectedSpeeds, speeds, "Speeds match expected speeds")
+	assert.Equal(t, expectedUnits, units, "Units match expected units")
+}
+
+func TestBandwidth_ComparisonAndArithmetic(t *testing.T) {
+	//given
+	mb, _ := NewBandwidth(1000, SpeedUnitMB)
+	gb, _ := NewBandwidth(1, SpeedUnitGB)
+	//then
+	assert.Equal(t, 0, mb.Cmp(gb), "1000 MB equals 1 GB")
+	assert.Equal(t, -1, (500 * Megabit).Cmp(gb), "500 MB is lower than 1 GB")
+	assert.Equal(t, 1, (2 * Gigabit).Cmp(gb), "2 GB is higher than 1 GB")
+	assert.Equal(t, "1500MB", (gb + 500*Megabit).String(), "Sum is normalized")
+	assert.Equal(t, "2GB", (3*Gigabit - gb).String(), "Difference is normalized")
+	assert.Equal(t, "0.5MB", Bandwidth(500000).String(), "Fractions of a megabit are represented")
+	assert.Equal(t, 25.0, (250 * Megabit).Percent(gb), "Percent matches")
+	assert.Equal(t, 1.5, (1500 * Megabit).Gigabits(), "Gigabits match")
+	assert.Equal(t, 1500.0, (1500 * Megabit).Megabits(), "Megabits match")
+}
+
+func TestL2ServiceProfile_SupportsBandwidth(t *testing.T) {
+	//given
+	profile := testProfile
+	customProfile := testProfile
+	customProfile.AllowCustomSpeed = Bool(true)
+	profile.AllowCustomSpeed = Bool(false)
+	//then
+	assert.True(t, profile.SupportsBandwidth(Gigabit), "1 GB matches 1000 MB speed band")
+	assert.False(t, profile.SupportsBandwidth(200*Megabit), "200 MB does not match any speed band")
+	assert.True(t, customProfile.SupportsBandwidth(200*Megabit), "Any bandwidth is supported with custom speed")
+}
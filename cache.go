@@ -0,0 +1,519 @@
+package ecx
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	//CacheGetUserPorts identifies GetUserPorts method in caching client configuration
+	CacheGetUserPorts = "GetUserPorts"
+	//CacheGetUserPort identifies GetUserPort method in caching client configuration
+	CacheGetUserPort = "GetUserPort"
+	//CacheGetL2OutgoingConnections identifies GetL2OutgoingConnections method in caching client configuration
+	CacheGetL2OutgoingConnections = "GetL2OutgoingConnections"
+	//CacheGetL2Connection identifies GetL2Connection method in caching client configuration
+	CacheGetL2Connection = "GetL2Connection"
+	//CacheGetDevices identifies GetDevices method in caching client configuration
+	CacheGetDevices = "GetDevices"
+	//CacheGetDevice identifies GetDevice method in caching client configuration
+	CacheGetDevice = "GetDevice"
+	//CacheGetL2SellerProfiles identifies GetL2SellerProfiles method in caching client configuration
+	CacheGetL2SellerProfiles = "GetL2SellerProfiles"
+	//CacheGetL2OwnServiceProfiles identifies GetL2OwnServiceProfiles method in caching client configuration
+	CacheGetL2OwnServiceProfiles = "GetL2OwnServiceProfiles"
+	//CacheGetL2ServiceProfile identifies GetL2ServiceProfile method in caching client configuration
+	CacheGetL2ServiceProfile = "GetL2ServiceProfile"
+	//CacheGetEventSubscriptions identifies GetEventSubscriptions method in caching client configuration
+	CacheGetEventSubscriptions = "GetEventSubscriptions"
+
+	cacheKeySeparator = "\x00"
+)
+
+//CacheStats describes cache usage of a single client method
+type CacheStats struct {
+	//Hits is a number of calls served from cache
+	Hits uint64
+	//Misses is a number of calls that invoked underlying client
+	Misses uint64
+	//Shared is a number of calls that waited for identical call in progress
+	//instead of invoking underlying client
+	Shared uint64
+	//Invalidations is a number of cache entries dropped due to mutating calls
+	Invalidations uint64
+}
+
+//CachingClient is a Client decorator that caches results of read methods.
+//Only methods with TTL set with WithTTL are cached, remaining calls are passed
+//to underlying client. Concurrent identical calls of cached methods share single
+//underlying call. Mutating calls invalidate cached results they affect, i.e.
+//CreateL2ServiceProfile invalidates service profile lists and DeleteL2Connection
+//invalidates connection lists and the deleted connection.
+//
+//Cached results are shared between callers and must not be modified
+type CachingClient struct {
+	Client
+	ttls    map[string]time.Duration
+	now     func() time.Time
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+	calls   map[string]*cacheCall
+	stats   map[string]*CacheStats
+	epoch   uint64
+}
+
+type cacheEntry struct {
+	value   interface{}
+	expires time.Time
+}
+
+type cacheCall struct {
+	wg    sync.WaitGroup
+	value interface{}
+	err   error
+}
+
+//NewCachingClient creates new caching decorator of a given client.
+//No methods are cached until TTLs are set with WithTTL
+func NewCachingClient(client Client) *CachingClient {
+	return &CachingClient{
+		Client:  client,
+		ttls:    make(map[string]time.Duration),
+		now:     time.Now,
+		entries: make(map[string]cacheEntry),
+		calls:   make(map[string]*cacheCall),
+		stats:   make(map[string]*CacheStats),
+	}
+}
+
+//WithTTL enables caching of a given method, i.e. CacheGetUserPorts, for a given time
+func (c *CachingClient) WithTTL(method string, ttl time.Duration) *CachingClient {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ttls[method] = ttl
+	return c
+}
+
+//Stats returns cache usage statistics of each method that was called or invalidated
+func (c *CachingClient) Stats() map[string]CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats := make(map[string]CacheStats, len(c.stats))
+	for method, s := range c.stats {
+		stats[method] = *s
+	}
+	return stats
+}
+
+//InvalidateAll drops all cached results
+func (c *CachingClient) InvalidateAll() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.epoch++
+	for key := range c.entries {
+		c.methodStats(cacheKeyMethod(key)).Invalidations++
+		delete(c.entries, key)
+	}
+}
+
+//GetUserPorts returns cached or fetched list of user ports
+func (c *CachingClient) GetUserPorts() ([]Port, error) {
+	v, err := c.get(CacheGetUserPorts, nil, func() (interface{}, error) { return c.Client.GetUserPorts() })
+	if err != nil {
+		return nil, err
+	}
+	return v.([]Port), nil
+}
+
+//GetUserPort returns cached or fetched user port with a given UUID
+func (c *CachingClient) GetUserPort(uuid string) (*Port, error) {
+	v, err := c.get(CacheGetUserPort, []string{uuid}, func() (interface{}, error) { return c.Client.GetUserPort(uuid) })
+	if err != nil {
+		return nil, err
+	}
+	return v.(*Port), nil
+}
+
+//GetL2OutgoingConnections returns cached or fetched list of outgoing connections with given statuses
+func (c *CachingClient) GetL2OutgoingConnections(statuses []string) ([]L2Connection, error) {
+	v, err := c.get(CacheGetL2OutgoingConnections, statuses, func() (interface{}, error) { return c.Client.GetL2OutgoingConnections(statuses) })
+	if err != nil {
+		return nil, err
+	}
+	return v.([]L2Connection), nil
+}
+
+//GetL2Connection returns cached or fetched connection with a given UUID
+func (c *CachingClient) GetL2Connection(uuid string) (*L2Connection, error) {
+	v, err := c.get(CacheGetL2Connection, []string{uuid}, func() (interface{}, error) { return c.Client.GetL2Connection(uuid) })
+	if err != nil {
+		return nil, err
+	}
+	return v.(*L2Connection), nil
+}
+
+//GetDevices returns cached or fetched list of virtual devices with given statuses
+func (c *CachingClient) GetDevices(statuses []string) ([]Device, error) {
+	v, err := c.get(CacheGetDevices, statuses, func() (interface{}, error) { return c.Client.GetDevices(statuses) })
+	if err != nil {
+		return nil, err
+	}
+	return v.([]Device), nil
+}
+
+//GetDevice returns cached or fetched virtual device with a given UUID
+func (c *CachingClient) GetDevice(uuid string) (*Device, error) {
+	v, err := c.get(CacheGetDevice, []string{uuid}, func() (interface{}, error) { return c.Client.GetDevice(uuid) })
+	if err != nil {
+		return nil, err
+	}
+	return v.(*Device), nil
+}
+
+//GetL2SellerProfiles returns cached or fetched list of seller profiles
+func (c *CachingClient) GetL2SellerProfiles() ([]L2ServiceProfile, error) {
+	v, err := c.get(CacheGetL2SellerProfiles, nil, func() (interface{}, error) { return c.Client.GetL2SellerProfiles() })
+	if err != nil {
+		return nil, err
+	}
+	return v.([]L2ServiceProfile), nil
+}
+
+//GetL2OwnServiceProfiles returns cached or fetched list of own service profiles with given states
+func (c *CachingClient) GetL2OwnServiceProfiles(states []string) ([]L2ServiceProfile, error) {
+	v, err := c.get(CacheGetL2OwnServiceProfiles, states, func() (interface{}, error) { return c.Client.GetL2OwnServiceProfiles(states) })
+	if err != nil {
+		return nil, err
+	}
+	return v.([]L2ServiceProfile), nil
+}
+
+//GetL2ServiceProfile returns cached or fetched service profile with a given UUID
+func (c *CachingClient) GetL2ServiceProfile(uuid string) (*L2ServiceProfile, error) {
+	v, err := c.get(CacheGetL2ServiceProfile, []string{uuid}, func() (interface{}, error) { return c.Client.GetL2ServiceProfile(uuid) })
+	if err != nil {
+		return nil, err
+	}
+	return v.(*L2ServiceProfile), nil
+}
+
+//GetEventSubscriptions returns cached or fetched list of event subscriptions
+func (c *CachingClient) GetEventSubscriptions() ([]EventSubscription, error) {
+	v, err := c.get(CacheGetEventSubscriptions, nil, func() (interface{}, error) { return c.Client.GetEventSubscriptions() })
+	if err != nil {
+		return nil, err
+	}
+	return v.([]EventSubscription), nil
+}
+
+//CreateL2Connection creates connection and invalidates outgoing connection lists
+func (c *CachingClient) CreateL2Connection(conn L2Connection) (*string, error) {
+	defer c.invalidateConnection("")
+	return c.Client.CreateL2Connection(conn)
+}
+
+//CreateL2RedundantConnection creates redundant connection and invalidates outgoing connection lists
+func (c *CachingClient) CreateL2RedundantConnection(priConn L2Connection, secConn L2Connection) (*string, *string, error) {
+	defer c.invalidateConnection("")
+	return c.Client.CreateL2RedundantConnection(priConn, secConn)
+}
+
+//CreateDeviceL2Connection creates device connection and invalidates outgoing connection lists
+//along with device lists and the device
+func (c *CachingClient) CreateDeviceL2Connection(conn L2Connection) (*string, error) {
+	defer c.invalidateDevices(StringValue(conn.DeviceUUID))
+	defer c.invalidateConnection("")
+	return c.Client.CreateDeviceL2Connection(conn)
+}
+
+//CreateDeviceL2RedundantConnection creates redundant device connection and invalidates
+//outgoing connection lists along with device lists and the devices
+func (c *CachingClient) CreateDeviceL2RedundantConnection(priConn L2Connection, secConn L2Connection) (*string, *string, error) {
+	defer c.invalidateDevices(StringValue(priConn.DeviceUUID), StringValue(secConn.DeviceUUID))
+	defer c.invalidateConnection("")
+	return c.Client.CreateDeviceL2RedundantConnection(priConn, secConn)
+}
+
+//CreateDeviceToDeviceL2Connection creates device to device connection and invalidates
+//outgoing connection lists along with device lists and both devices
+func (c *CachingClient) CreateDeviceToDeviceL2Connection(conn L2Connection) (*string, error) {
+	defer c.invalidateDevices(StringValue(conn.DeviceUUID), StringValue(conn.ZSideDeviceUUID))
+	defer c.invalidateConnection("")
+	return c.Client.CreateDeviceToDeviceL2Connection(conn)
+}
+
+//NewL2ConnectionUpdateRequest creates connection update request that invalidates
+//the connection and outgoing connection lists upon execution
+func (c *CachingClient) NewL2ConnectionUpdateRequest(uuid string) L2ConnectionUpdateRequest {
+	return &cachingL2ConnectionUpdateRequest{
+		L2ConnectionUpdateRequest: c.Client.NewL2ConnectionUpdateRequest(uuid),
+		c:                         c,
+		uuid:                      uuid,
+	}
+}
+
+//DeleteL2Connection deletes connection and invalidates it along with outgoing connection lists
+func (c *CachingClient) DeleteL2Connection(uuid string) error {
+	defer c.invalidateConnection(uuid)
+	return c.Client.DeleteL2Connection(uuid)
+}
+
+//DeleteDeviceL2Connection deletes device connection and invalidates it along with
+//outgoing connection lists and all devices, as connection's devices are not known
+func (c *CachingClient) DeleteDeviceL2Connection(uuid string) error {
+	defer c.invalidateDevices()
+	defer c.invalidateConnection(uuid)
+	return c.Client.DeleteDeviceL2Connection(uuid)
+}
+
+//ConfirmL2Connection confirms connection and invalidates it along with outgoing connection lists
+func (c *CachingClient) ConfirmL2Connection(uuid string, confirmConn L2ConnectionToConfirm) (*L2ConnectionConfirmation, error) {
+	defer c.invalidateConnection(uuid)
+	return c.Client.ConfirmL2Connection(uuid, confirmConn)
+}
+
+//PerformL2ConnectionAction performs connection action and invalidates the connection
+//along with outgoing connection lists
+func (c *CachingClient) PerformL2ConnectionAction(uuid string, operationID string, data map[string]string) (*L2Connection, error) {
+	defer c.invalidateConnection(uuid)
+	return c.Client.PerformL2ConnectionAction(uuid, operationID, data)
+}
+
+//CreateL2ServiceProfile creates service profile and invalidates service profile lists
+func (c *CachingClient) CreateL2ServiceProfile(sp L2ServiceProfile) (*string, error) {
+	defer c.invalidateServiceProfile("")
+	return c.Client.CreateL2ServiceProfile(sp)
+}
+
+//CloneL2ServiceProfile clones service profile and invalidates service profile lists
+func (c *CachingClient) CloneL2ServiceProfile(sourceUUID string, overrides L2ServiceProfile) (*string, error) {
+	defer c.invalidateServiceProfile("")
+	return c.Client.CloneL2ServiceProfile(sourceUUID, overrides)
+}
+
+//UpdateL2ServiceProfile updates service profile and invalidates it along with service profile lists
+func (c *CachingClient) UpdateL2ServiceProfile(sp L2ServiceProfile) error {
+	defer c.invalidateServiceProfile(StringValue(sp.UUID))
+	return c.Client.UpdateL2ServiceProfile(sp)
+}
+
+//NewL2ServiceProfileUpdateRequest creates service profile update request that invalidates
+//the profile and service profile lists upon execution
+func (c *CachingClient) NewL2ServiceProfileUpdateRequest(uuid string) L2ServiceProfileUpdateRequest {
+	return &cachingL2ServiceProfileUpdateRequest{
+		L2ServiceProfileUpdateRequest: c.Client.NewL2ServiceProfileUpdateRequest(uuid),
+		c:                             c,
+		uuid:                          uuid,
+	}
+}
+
+//DeleteL2ServiceProfile deletes service profile and invalidates it along with service profile lists
+func (c *CachingClient) DeleteL2ServiceProfile(uuid string) error {
+	defer c.invalidateServiceProfile(uuid)
+	return c.Client.DeleteL2ServiceProfile(uuid)
+}
+
+//CreateEventSubscription creates event subscription and invalidates event subscription list
+func (c *CachingClient) CreateEventSubscription(subscription EventSubscription) (*string, error) {
+	defer c.invalidate(CacheGetEventSubscriptions, nil)
+	return c.Client.CreateEventSubscription(subscription)
+}
+
+//DeleteEventSubscription deletes event subscription and invalidates event subscription list
+func (c *CachingClient) DeleteEventSubscription(uuid string) error {
+	defer c.invalidate(CacheGetEventSubscriptions, nil)
+	return c.Client.DeleteEventSubscription(uuid)
+}
+
+type cachingL2ConnectionUpdateRequest struct {
+	L2ConnectionUpdateRequest
+	c    *CachingClient
+	uuid string
+}
+
+func (req *cachingL2ConnectionUpdateRequest) WithName(name string) L2ConnectionUpdateRequest {
+	req.L2ConnectionUpdateRequest.WithName(name)
+	return req
+}
+
+func (req *cachingL2ConnectionUpdateRequest) WithBandwidth(speed int, speedUnit string) L2ConnectionUpdateRequest {
+	req.L2ConnectionUpdateRequest.WithBandwidth(speed, speedUnit)
+	return req
+}
+
+func (req *cachingL2ConnectionUpdateRequest) WithSpeed(speed int) L2ConnectionUpdateRequest {
+	req.L2ConnectionUpdateRequest.WithSpeed(speed)
+	return req
+}
+
+func (req *cachingL2ConnectionUpdateRequest) WithSpeedUnit(speedUnit string) L2ConnectionUpdateRequest {
+	req.L2ConnectionUpdateRequest.WithSpeedUnit(speedUnit)
+	return req
+}
+
+func (req *cachingL2ConnectionUpdateRequest) Execute() error {
+	defer req.c.invalidateConnection(req.uuid)
+	return req.L2ConnectionUpdateRequest.Execute()
+}
+
+type cachingL2ServiceProfileUpdateRequest struct {
+	L2ServiceProfileUpdateRequest
+	c    *CachingClient
+	uuid string
+}
+
+func (req *cachingL2ServiceProfileUpdateRequest) WithName(name string) L2ServiceProfileUpdateRequest {
+	req.L2ServiceProfileUpdateRequest.WithName(name)
+	return req
+}
+
+func (req *cachingL2ServiceProfileUpdateRequest) WithDescription(description string) L2ServiceProfileUpdateRequest {
+	req.L2ServiceProfileUpdateRequest.WithDescription(description)
+	return req
+}
+
+func (req *cachingL2ServiceProfileUpdateRequest) WithSpeedBands(bands []L2ServiceProfileSpeedBand) L2ServiceProfileUpdateRequest {
+	req.L2ServiceProfileUpdateRequest.WithSpeedBands(bands)
+	return req
+}
+
+func (req *cachingL2ServiceProfileUpdateRequest) WithOnBandwidthThresholdNotification(emails []string) L2ServiceProfileUpdateRequest {
+	req.L2ServiceProfileUpdateRequest.WithOnBandwidthThresholdNotification(emails)
+	return req
+}
+
+func (req *cachingL2ServiceProfileUpdateRequest) WithOnProfileApprovalRejectNotification(emails []string) L2ServiceProfileUpdateRequest {
+	req.L2ServiceProfileUpdateRequest.WithOnProfileApprovalRejectNotification(emails)
+	return req
+}
+
+func (req *cachingL2ServiceProfileUpdateRequest) WithOnVcApprovalRejectionNotification(emails []string) L2ServiceProfileUpdateRequest {
+	req.L2ServiceProfileUpdateRequest.WithOnVcApprovalRejectionNotification(emails)
+	return req
+}
+
+func (req *cachingL2ServiceProfileUpdateRequest) WithPrivateUserEmails(emails []string) L2ServiceProfileUpdateRequest {
+	req.L2ServiceProfileUpdateRequest.WithPrivateUserEmails(emails)
+	return req
+}
+
+func (req *cachingL2ServiceProfileUpdateRequest) WithPorts(ports []L2ServiceProfilePort) L2ServiceProfileUpdateRequest {
+	req.L2ServiceProfileUpdateRequest.WithPorts(ports)
+	return req
+}
+
+func (req *cachingL2ServiceProfileUpdateRequest) Execute() error {
+	defer req.c.invalidateServiceProfile(req.uuid)
+	return req.L2ServiceProfileUpdateRequest.Execute()
+}
+
+//get returns cached result of a given method call or invokes fetch function.
+//Concurrent calls with the same key wait for a single fetch
+func (c *CachingClient) get(method string, args []string, fetch func() (interface{}, error)) (interface{}, error) {
+	c.mu.Lock()
+	ttl, cached := c.ttls[method]
+	if !cached || ttl <= 0 {
+		c.mu.Unlock()
+		return fetch()
+	}
+	key := cacheKey(method, args)
+	stats := c.methodStats(method)
+	if entry, ok := c.entries[key]; ok && c.now().Before(entry.expires) {
+		stats.Hits++
+		c.mu.Unlock()
+		return entry.value, nil
+	}
+	if call, ok := c.calls[key]; ok {
+		stats.Shared++
+		c.mu.Unlock()
+		call.wg.Wait()
+		return call.value, call.err
+	}
+	stats.Misses++
+	call := &cacheCall{}
+	call.wg.Add(1)
+	c.calls[key] = call
+	epoch := c.epoch
+	c.mu.Unlock()
+
+	call.value, call.err = fetch()
+
+	c.mu.Lock()
+	delete(c.calls, key)
+	//result fetched while cache was invalidated may be stale, so it is not stored
+	if call.err == nil && epoch == c.epoch {
+		c.entries[key] = cacheEntry{value: call.value, expires: c.now().Add(ttl)}
+	}
+	c.mu.Unlock()
+	call.wg.Done()
+	return call.value, call.err
+}
+
+func (c *CachingClient) invalidateConnection(uuid string) {
+	c.invalidate(CacheGetL2OutgoingConnections, nil)
+	if uuid != "" {
+		c.invalidate(CacheGetL2Connection, []string{uuid})
+	}
+}
+
+//invalidateDevices drops device lists along with devices with given UUIDs,
+//or all devices when no UUID is given
+func (c *CachingClient) invalidateDevices(uuids ...string) {
+	c.invalidate(CacheGetDevices, nil)
+	if len(uuids) == 0 {
+		c.invalidate(CacheGetDevice, nil)
+	}
+	for _, uuid := range uuids {
+		if uuid != "" {
+			c.invalidate(CacheGetDevice, []string{uuid})
+		}
+	}
+}
+
+func (c *CachingClient) invalidateServiceProfile(uuid string) {
+	c.invalidate(CacheGetL2SellerProfiles, nil)
+	c.invalidate(CacheGetL2OwnServiceProfiles, nil)
+	if uuid != "" {
+		c.invalidate(CacheGetL2ServiceProfile, []string{uuid})
+	}
+}
+
+//invalidate drops cached results of a given method. When args are given,
+//only result of a call with given arguments is dropped
+func (c *CachingClient) invalidate(method string, args []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.epoch++
+	if args != nil {
+		key := cacheKey(method, args)
+		if _, ok := c.entries[key]; ok {
+			delete(c.entries, key)
+			c.methodStats(method).Invalidations++
+		}
+		return
+	}
+	for key := range c.entries {
+		if cacheKeyMethod(key) == method {
+			delete(c.entries, key)
+			c.methodStats(method).Invalidations++
+		}
+	}
+}
+
+func (c *CachingClient) methodStats(method string) *CacheStats {
+	stats, ok := c.stats[method]
+	if !ok {
+		stats = &CacheStats{}
+		c.stats[method] = stats
+	}
+	return stats
+}
+
+func cacheKey(method string, args []string) string {
+	return method + cacheKeySeparator + strings.Join(args, cacheKeySeparator)
+}
+
+func cacheKeyMethod(key string) string {
+	return key[:strings.Index(key, cacheKeySeparator)]
+}
@@ -0,0 +1,274 @@
+package ecx
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+//stubCacheClient counts calls of underlying client methods used in caching tests.
+//Methods that are not overridden panic, as embedded Client is nil
+type stubCacheClient struct {
+	Client
+	calls         map[string]*int32
+	release       chan struct{}
+	err           error
+	profileUpdate *stubProfileUpdateRequest
+}
+
+type stubProfileUpdateRequest struct {
+	L2ServiceProfileUpdateRequest
+	name     string
+	executed bool
+}
+
+func (r *stubProfileUpdateRequest) WithName(name string) L2ServiceProfileUpdateRequest {
+	r.name = name
+	return r
+}
+
+func (r *stubProfileUpdateRequest) Execute() error {
+	r.executed = true
+	return nil
+}
+
+func newStubCacheClient() *stubCacheClient {
+	return &stubCacheClient{
+		calls:         make(map[string]*int32),
+		profileUpdate: &stubProfileUpdateRequest{},
+	}
+}
+
+func (s *stubCacheClient) count(method string) int {
+	if c, ok := s.calls[method]; ok {
+		return int(atomic.LoadInt32(c))
+	}
+	return 0
+}
+
+func (s *stubCacheClient) called(method string) {
+	atomic.AddInt32(s.calls[method], 1)
+}
+
+func (s *stubCacheClient) GetUserPorts() ([]Port, error) {
+	s.called(CacheGetUserPorts)
+	if s.err != nil {
+		return nil, s.err
+	}
+	return []Port{{UUID: String("port-1")}}, nil
+}
+
+func (s *stubCacheClient) GetL2SellerProfiles() ([]L2ServiceProfile, error) {
+	s.called(CacheGetL2SellerProfiles)
+	if s.release != nil {
+		<-s.release
+	}
+	return []L2ServiceProfile{{UUID: String("profile-1")}}, nil
+}
+
+func (s *stubCacheClient) GetL2ServiceProfile(uuid string) (*L2ServiceProfile, error) {
+	s.called(CacheGetL2ServiceProfile)
+	return &L2ServiceProfile{UUID: String(uuid)}, nil
+}
+
+func (s *stubCacheClient) CreateL2ServiceProfile(sp L2ServiceProfile) (*string, error) {
+	return String("profile-2"), nil
+}
+
+func (s *stubCacheClient) NewL2ServiceProfileUpdateRequest(uuid string) L2ServiceProfileUpdateRequest {
+	return s.profileUpdate
+}
+
+func (s *stubCacheClient) GetL2Connection(uuid string) (*L2Connection, error) {
+	s.called(CacheGetL2Connection)
+	return &L2Connection{UUID: String(uuid)}, nil
+}
+
+func (s *stubCacheClient) DeleteL2Connection(uuid string) error {
+	return nil
+}
+
+func (s *stubCacheClient) GetDevice(uuid string) (*Device, error) {
+	s.called(CacheGetDevice)
+	return &Device{UUID: String(uuid)}, nil
+}
+
+func (s *stubCacheClient) CreateDeviceToDeviceL2Connection(conn L2Connection) (*string, error) {
+	return String("conn-3"), nil
+}
+
+func (s *stubCacheClient) DeleteDeviceL2Connection(uuid string) error {
+	return nil
+}
+
+func newTestCachingClient(stub *stubCacheClient, methods ...string) (*CachingClient, *time.Time) {
+	now := time.Date(2022, time.August, 1, 12, 0, 0, 0, time.UTC)
+	client := NewCachingClient(stub)
+	client.now = func() time.Time { return now }
+	for _, method := range methods {
+		stub.calls[method] = new(int32)
+		client.WithTTL(method, time.Minute)
+	}
+	return client, &now
+}
+
+func TestCachingClient_hitAndExpiry(t *testing.T) {
+	//Given
+	stub := newStubCacheClient()
+	client, now := newTestCachingClient(stub, CacheGetUserPorts)
+
+	//When
+	first, err := client.GetUserPorts()
+	second, _ := client.GetUserPorts()
+	*now = now.Add(2 * time.Minute)
+	client.GetUserPorts()
+
+	//Then
+	assert.Nil(t, err, "Client should not return an error")
+	assert.Equal(t, first, second, "Cached result matches")
+	assert.Equal(t, 2, stub.count(CacheGetUserPorts), "Underlying client is called on miss and after expiry")
+	assert.Equal(t, CacheStats{Hits: 1, Misses: 2}, client.Stats()[CacheGetUserPorts], "Stats match")
+}
+
+func TestCachingClient_notConfigured(t *testing.T) {
+	//Given
+	stub := newStubCacheClient()
+	stub.calls[CacheGetUserPorts] = new(int32)
+	client := NewCachingClient(stub)
+
+	//When
+	client.GetUserPorts()
+	client.GetUserPorts()
+
+	//Then
+	assert.Equal(t, 2, stub.count(CacheGetUserPorts), "Calls are passed to underlying client")
+	assert.Empty(t, client.Stats(), "No stats are collected")
+}
+
+func TestCachingClient_errorsNotCached(t *testing.T) {
+	//Given
+	stub := newStubCacheClient()
+	stub.err = errors.New("boom")
+	client, _ := newTestCachingClient(stub, CacheGetUserPorts)
+
+	//When
+	_, err := client.GetUserPorts()
+	stub.err = nil
+	ports, _ := client.GetUserPorts()
+
+	//Then
+	assert.NotNil(t, err, "Client should return an error")
+	assert.Equal(t, 1, len(ports), "Result is fetched after error")
+	assert.Equal(t, 2, stub.count(CacheGetUserPorts), "Error is not cached")
+}
+
+func TestCachingClient_concurrentCallsShared(t *testing.T) {
+	//Given
+	stub := newStubCacheClient()
+	stub.release = make(chan struct{})
+	client, _ := newTestCachingClient(stub, CacheGetL2SellerProfiles)
+	callers := 10
+	wg := sync.WaitGroup{}
+
+	//When
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			client.GetL2SellerProfiles()
+		}()
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for client.Stats()[CacheGetL2SellerProfiles].Shared < uint64(callers-1) && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	close(stub.release)
+	wg.Wait()
+
+	//Then
+	assert.Equal(t, 1, stub.count(CacheGetL2SellerProfiles), "Underlying client is called once")
+	assert.Equal(t, CacheStats{Misses: 1, Shared: uint64(callers - 1)}, client.Stats()[CacheGetL2SellerProfiles], "Stats match")
+}
+
+func TestCachingClient_invalidation(t *testing.T) {
+	//Given
+	stub := newStubCacheClient()
+	client, _ := newTestCachingClient(stub, CacheGetL2SellerProfiles, CacheGetL2ServiceProfile, CacheGetL2Connection)
+	client.GetL2SellerProfiles()
+	client.GetL2ServiceProfile("profile-1")
+	client.GetL2Connection("conn-1")
+	client.GetL2Connection("conn-2")
+
+	//When
+	client.CreateL2ServiceProfile(L2ServiceProfile{})
+	client.DeleteL2Connection("conn-1")
+	client.GetL2SellerProfiles()
+	client.GetL2ServiceProfile("profile-1")
+	client.GetL2Connection("conn-1")
+	client.GetL2Connection("conn-2")
+
+	//Then
+	assert.Equal(t, 2, stub.count(CacheGetL2SellerProfiles), "Profile list is invalidated by profile creation")
+	assert.Equal(t, 1, stub.count(CacheGetL2ServiceProfile), "Single profile is not invalidated by profile creation")
+	assert.Equal(t, 3, stub.count(CacheGetL2Connection), "Only deleted connection is invalidated")
+	assert.Equal(t, uint64(1), client.Stats()[CacheGetL2Connection].Invalidations, "Invalidations match")
+}
+
+func TestCachingClient_deviceConnectionInvalidatesDevices(t *testing.T) {
+	//Given
+	stub := newStubCacheClient()
+	client, _ := newTestCachingClient(stub, CacheGetDevice)
+	client.GetDevice("device-1")
+	client.GetDevice("device-2")
+	client.GetDevice("device-3")
+
+	//When
+	client.CreateDeviceToDeviceL2Connection(L2Connection{DeviceUUID: String("device-1"), ZSideDeviceUUID: String("device-2")})
+	client.GetDevice("device-1")
+	client.GetDevice("device-2")
+	client.GetDevice("device-3")
+	created := stub.count(CacheGetDevice)
+	client.DeleteDeviceL2Connection("conn-3")
+	client.GetDevice("device-1")
+	client.GetDevice("device-2")
+	client.GetDevice("device-3")
+
+	//Then
+	assert.Equal(t, 5, created, "Only connection's devices are invalidated by connection creation")
+	assert.Equal(t, 8, stub.count(CacheGetDevice), "All devices are invalidated by connection deletion")
+}
+
+func TestCachingClient_updateRequestInvalidates(t *testing.T) {
+	//Given
+	stub := newStubCacheClient()
+	client, _ := newTestCachingClient(stub, CacheGetL2ServiceProfile)
+	client.GetL2ServiceProfile("profile-1")
+
+	//When
+	err := client.NewL2ServiceProfileUpdateRequest("profile-1").WithName("new").Execute()
+	client.GetL2ServiceProfile("profile-1")
+
+	//Then
+	assert.Nil(t, err, "Update should not return an error")
+	assert.Equal(t, "new", stub.profileUpdate.name, "Update is passed to underlying request")
+	assert.True(t, stub.profileUpdate.executed, "Underlying request is executed")
+	assert.Equal(t, 2, stub.count(CacheGetL2ServiceProfile), "Updated profile is invalidated")
+}
+
+func TestCachingClient_InvalidateAll(t *testing.T) {
+	//Given
+	stub := newStubCacheClient()
+	client, _ := newTestCachingClient(stub, CacheGetUserPorts)
+	client.GetUserPorts()
+
+	//When
+	client.InvalidateAll()
+	client.GetUserPorts()
+
+	//Then
+	assert.Equal(t, 2, stub.count(CacheGetUserPorts), "All results are invalidated")
+}
@@ -1,6 +1,8 @@
 //Package ecx implements Equinix Fabric client
 package ecx
 
+import "time"
+
 const (
 	//ConnectionStatusNotAvailable indicates that request to create connection was not sent
 	//to the provider. Applicable for provider status only
@@ -36,9 +38,27 @@ const (
 	ConnectionStatusDeleted = "DELETED"
 )
 
+const (
+	//TrafficDirectionInbound indicates traffic received on a port or a connection
+	TrafficDirectionInbound = "INBOUND"
+	//TrafficDirectionOutbound indicates traffic sent from a port or a connection
+	TrafficDirectionOutbound = "OUTBOUND"
+)
+
+const (
+	//VlanTagMin is the lowest VLAN tag that can be used by a connection on a user port
+	VlanTagMin = 2
+	//VlanTagMax is the highest VLAN tag that can be used by a connection on a user port
+	VlanTagMax = 4094
+)
+
 //Client describes operations provided by Equinix Fabric client module
 type Client interface {
 	GetUserPorts() ([]Port, error)
+	GetUserPort(uuid string) (*Port, error)
+	GetPortVlanAvailability(uuid string) (*PortVlanAvailability, error)
+	GetPortCapacity(uuid string) (*PortCapacity, error)
+	GetPortStats(uuid string, from, to time.Time, interval time.Duration) (*TrafficStatistics, error)
 
 	GetL2OutgoingConnections(statuses []string) ([]L2Connection, error)
 	GetL2Connection(uuid string) (*L2Connection, error)
@@ -47,12 +67,57 @@ type Client interface {
 	NewL2ConnectionUpdateRequest(uuid string) L2ConnectionUpdateRequest
 	DeleteL2Connection(uuid string) error
 	ConfirmL2Connection(uuid string, confirmConn L2ConnectionToConfirm) (*L2ConnectionConfirmation, error)
+	PerformL2ConnectionAction(uuid string, operationID string, data map[string]string) (*L2Connection, error)
+	GetL2ConnectionStats(uuid string, from, to time.Time, interval time.Duration) (*TrafficStatistics, error)
+
+	GetDevices(statuses []string) ([]Device, error)
+	GetDevice(uuid string) (*Device, error)
+	GetDeviceFreeInterfaces(uuid string) ([]DeviceInterface, error)
+	CreateDeviceL2Connection(conn L2Connection) (*string, error)
+	CreateDeviceL2RedundantConnection(priConn L2Connection, secConn L2Connection) (*string, *string, error)
+	CreateDeviceToDeviceL2Connection(conn L2Connection) (*string, error)
+	GetDeviceL2Connection(uuid string) (*L2Connection, error)
+	DeleteDeviceL2Connection(uuid string) error
 
 	GetL2SellerProfiles() ([]L2ServiceProfile, error)
+	SearchL2SellerProfiles(query L2SellerProfileQuery) ([]L2ServiceProfile, error)
+	GetL2OwnServiceProfiles(states []string) ([]L2ServiceProfile, error)
 	GetL2ServiceProfile(uuid string) (*L2ServiceProfile, error)
 	CreateL2ServiceProfile(sp L2ServiceProfile) (*string, error)
+	CloneL2ServiceProfile(sourceUUID string, overrides L2ServiceProfile) (*string, error)
 	UpdateL2ServiceProfile(sp L2ServiceProfile) error
+	NewL2ServiceProfileUpdateRequest(uuid string) L2ServiceProfileUpdateRequest
 	DeleteL2ServiceProfile(uuid string) error
+
+	GetEventSubscriptions() ([]EventSubscription, error)
+	CreateEventSubscription(subscription EventSubscription) (*string, error)
+	DeleteEventSubscription(uuid string) error
+}
+
+//Device describes Network Edge virtual device that can originate layer 2 connections
+type Device struct {
+	UUID           *string
+	Name           *string
+	DeviceTypeCode *string
+	Status         *string
+	MetroCode      *string
+	IBX            *string
+	Region         *string
+	RedundancyType *string
+	RedundantUUID  *string
+	Interfaces     []DeviceInterface
+}
+
+//DeviceInterface describes Network Edge virtual device interface
+type DeviceInterface struct {
+	ID                *int
+	Name              *string
+	Status            *string
+	OperationalStatus *string
+	MACAddress        *string
+	IPAddress         *string
+	AssignedType      *string
+	Type              *string
 }
 
 //L2ConnectionUpdateRequest describes composite request to update given Layer2 connection
@@ -64,6 +129,43 @@ type L2ConnectionUpdateRequest interface {
 	Execute() error
 }
 
+//L2ServiceProfileUpdateRequest describes composite request to partially update given layer 2
+//service profile. Only fields set in the request are changed, remaining fields keep their
+//current values
+type L2ServiceProfileUpdateRequest interface {
+	WithName(name string) L2ServiceProfileUpdateRequest
+	WithDescription(description string) L2ServiceProfileUpdateRequest
+	WithSpeedBands(bands []L2ServiceProfileSpeedBand) L2ServiceProfileUpdateRequest
+	WithOnBandwidthThresholdNotification(emails []string) L2ServiceProfileUpdateRequest
+	WithOnProfileApprovalRejectNotification(emails []string) L2ServiceProfileUpdateRequest
+	WithOnVcApprovalRejectionNotification(emails []string) L2ServiceProfileUpdateRequest
+	WithPrivateUserEmails(emails []string) L2ServiceProfileUpdateRequest
+	WithPorts(ports []L2ServiceProfilePort) L2ServiceProfileUpdateRequest
+	Diff() ([]L2ServiceProfileChange, error)
+	Execute() error
+}
+
+//L2ServiceProfileChange describes change of a single service profile field
+//that will be applied by service profile update request
+type L2ServiceProfileChange struct {
+	Field string
+	From  string
+	To    string
+}
+
+//L2ServiceProfileModifiedError is returned when service profile update request detects
+//that profile was modified after it was fetched by the request. Detection is best-effort:
+//profile is compared right before the update is sent, API does not support update
+//preconditions, so modifications made between the comparison and the update are not detected
+type L2ServiceProfileModifiedError struct {
+	UUID string
+}
+
+//Error returns description of a service profile modification
+func (e L2ServiceProfileModifiedError) Error() string {
+	return "service profile " + e.UUID + " was modified after it was fetched"
+}
+
 //Error describes Equinix Fabric error that occurs during API call processing
 type Error struct {
 	//ErrorCode is short error identifier
@@ -93,6 +195,8 @@ type L2Connection struct {
 	ZSidePortUUID       *string
 	ZSideVlanSTag       *int
 	ZSideVlanCTag       *int
+	ZSideDeviceUUID     *string
+	ZSideInterfaceID    *int
 	SellerRegion        *string
 	SellerMetroCode     *string
 	AuthorizationKey    *string
@@ -209,6 +313,22 @@ type L2ServiceProfileFeatures struct {
 	TestProfile *bool
 }
 
+//L2SellerProfileQuery describes criteria used to search for layer 2 seller service profiles.
+//Empty criteria are not used for filtering
+type L2SellerProfileQuery struct {
+	//Name is matched, case insensitive, against profile name, organization name and
+	//global organization name. Exact matches rank higher than partial ones
+	Name string
+	//MetroCode limits results to profiles available in a given metro
+	MetroCode string
+	//Bandwidth limits results to profiles that support connections with a given bandwidth
+	Bandwidth Bandwidth
+	//Encapsulation limits results to profiles with a given encapsulation, i.e. Dot1q or Qinq
+	Encapsulation string
+	//CloudReach limits results to profiles with a given CloudReach feature flag
+	CloudReach *bool
+}
+
 //Port describes Equinix Fabric's user port
 type Port struct {
 	UUID          *string
@@ -219,8 +339,65 @@ type Port struct {
 	Priority      *string
 	Encapsulation *string
 	Buyout        *bool
-	Bandwidth     *string
-	Status        *string
+	// Bandwidth is port's total bandwidth in bits per second.
+	//
+	// Deprecated: Bandwidth is maintained for compatibility only. Use Speed and SpeedUnit instead.
+	Bandwidth *string
+	Status    *string
+	// Speed and SpeedUnit describe port's total bandwidth, i.e. 10 GB
+	Speed     *int
+	SpeedUnit *string
+	// OperationalStatus is provisioning status of port's physical ports. For LAG ports,
+	// status of the first member that is not provisioned is used
+	OperationalStatus *string
+	Device            *string
+	// RedundancyGroup identifies group of ports that back each other up,
+	// port's role within a group is described by Priority
+	RedundancyGroup   *string
+	IsLAG             *bool
+	Layer3Enabled     *bool
+	SharedPortType    *string
+	SharedPortProduct *string
+	PhysicalPorts     []PhysicalPort
+}
+
+//PhysicalPort describes physical port and its cross connect. User port with LAG
+//enabled consists of multiple physical ports
+type PhysicalPort struct {
+	Speed          *int
+	SpeedUnit      *string
+	CrossConnectID *string
+	CabinetNumber  *string
+	CageNumber     *string
+	PatchPanelName *string
+	TagProtocolID  *string
+	Status         *string
+}
+
+//PortVlanAvailability describes VLAN tags used by connections on a given user port
+type PortVlanAvailability struct {
+	PortUUID      *string
+	Encapsulation *string
+	UsedVlans     []PortVlan
+}
+
+//PortVlan describes VLAN tags used by a connection on a user port
+type PortVlan struct {
+	ConnectionUUID *string
+	STag           *int
+	CTag           *int
+}
+
+//PortCapacity describes total, allocated and remaining bandwidth of a user port.
+//Allocated bandwidth is a sum of speeds of connections originating from or terminating
+//on the port
+type PortCapacity struct {
+	PortUUID        *string
+	Speed           *int
+	AllocatedSpeed  *int
+	RemainingSpeed  *int
+	SpeedUnit       *string
+	ConnectionUUIDs []string
 }
 
 //L2SellerProfileMetro describes details of a metro in which service provices is present
@@ -239,3 +416,40 @@ type L2SellerProfileAdditionalInfo struct {
 	IsMandatory      *bool
 	IsCaptureInEmail *bool
 }
+
+//TrafficStatistics describes traffic time series collected for a port or a connection.
+//Speed and SpeedUnit describe capacity that utilization of each sample is computed against
+type TrafficStatistics struct {
+	UUID          *string
+	StartDateTime *time.Time
+	EndDateTime   *time.Time
+	Interval      *time.Duration
+	Speed         *int
+	SpeedUnit     *string
+	Samples       []TrafficSample
+}
+
+//TrafficSample describes traffic statistics for a single metric interval.
+//Bandwidth values are expressed in megabits per second, utilization values
+//in percent of the capacity
+type TrafficSample struct {
+	IntervalEndDateTime    *time.Time
+	InboundBandwidth       *float64
+	OutboundBandwidth      *float64
+	InboundPacketsDropped  *int64
+	OutboundPacketsDropped *int64
+	InboundUtilization     *float64
+	OutboundUtilization    *float64
+}
+
+//EventSubscription describes subscription that delivers Equinix Fabric event notifications
+//of given types to a given URL. Secret is used to sign notifications and is never returned
+//by GET operations
+type EventSubscription struct {
+	UUID       *string
+	Name       *string
+	URL        *string
+	EventTypes []string
+	Secret     *string
+	State      *string
+}
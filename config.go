@@ -0,0 +1,295 @@
+package ecx
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+const (
+	//EnvAPIEndpoint is environment variable with Equinix API base URL
+	EnvAPIEndpoint = "EQUINIX_API_ENDPOINT"
+	//EnvAPIClientID is environment variable with Equinix application client ID
+	EnvAPIClientID = "EQUINIX_API_CLIENTID"
+	//EnvAPIClientSecret is environment variable with Equinix application client secret
+	EnvAPIClientSecret = "EQUINIX_API_CLIENTSECRET"
+	//EnvAPITimeout is environment variable with API request timeout, either as a number
+	//of seconds or a duration, i.e. 90s
+	EnvAPITimeout = "EQUINIX_API_TIMEOUT"
+	//EnvAPIPageSize is environment variable with default page size of paginated collections
+	EnvAPIPageSize = "EQUINIX_API_PAGE_SIZE"
+	//EnvAPIUserAgent is environment variable with suffix of client's User-agent header
+	EnvAPIUserAgent = "EQUINIX_API_USER_AGENT"
+	//EnvAPIProxy is environment variable with proxy server URL
+	EnvAPIProxy = "EQUINIX_API_PROXY"
+	//EnvAPICAFile is environment variable with path to PEM file with root CA certificates
+	EnvAPICAFile = "EQUINIX_API_CA_FILE"
+	//EnvConfigFile is environment variable with path to configuration file
+	EnvConfigFile = "EQUINIX_CONFIG_FILE"
+	//EnvProfile is environment variable with name of configuration file profile
+	EnvProfile = "EQUINIX_PROFILE"
+
+	//EnvironmentProduction identifies Equinix production API environment
+	EnvironmentProduction = "production"
+	//EnvironmentSandbox identifies Equinix sandbox API environment
+	EnvironmentSandbox = "sandbox"
+)
+
+//errNoProfileSelected is returned when profile is not given and configuration file
+//has neither default profile nor a single profile
+var errNoProfileSelected = errors.New("no profile is selected")
+
+//environmentEndpoints maps API environments to their base URLs
+var environmentEndpoints = map[string]string{
+	EnvironmentProduction: "https://api.equinix.com",
+	EnvironmentSandbox:    "https://sandboxapi.equinix.com",
+}
+
+//Config describes Equinix Fabric client configuration
+type Config struct {
+	//Profile is a name of configuration file profile the configuration was loaded from
+	Profile string
+	//BaseURL is Equinix API base URL
+	BaseURL string
+	//ClientID is Equinix application client ID
+	ClientID string
+	//ClientSecret is Equinix application client secret
+	ClientSecret string
+	//Timeout is API request timeout, no timeout when zero
+	Timeout time.Duration
+	//PageSize is default page size of paginated collections, client's default when zero
+	PageSize int
+	//UserAgentSuffix is a suffix of client's User-agent header
+	UserAgentSuffix string
+	//Headers are headers added to every API request
+	Headers map[string]string
+	//ProxyURL is proxy server URL
+	ProxyURL string
+	//CAFile is a path to PEM file with root CA certificates that verify API server certificate
+	CAFile string
+}
+
+//configFile describes JSON configuration file with named profiles, i.e.
+//{"default_profile": "sandbox", "profiles": {"sandbox": {"environment": "sandbox", "client_id": "..."}}}
+type configFile struct {
+	DefaultProfile string                       `json:"default_profile,omitempty"`
+	Profiles       map[string]configFileProfile `json:"profiles,omitempty"`
+}
+
+type configFileProfile struct {
+	Environment  string            `json:"environment,omitempty"`
+	Endpoint     string            `json:"endpoint,omitempty"`
+	ClientID     string            `json:"client_id,omitempty"`
+	ClientSecret string            `json:"client_secret,omitempty"`
+	Timeout      string            `json:"timeout,omitempty"`
+	PageSize     int               `json:"page_size,omitempty"`
+	UserAgent    string            `json:"user_agent,omitempty"`
+	Headers      map[string]string `json:"headers,omitempty"`
+	Proxy        string            `json:"proxy,omitempty"`
+	CAFile       string            `json:"ca_file,omitempty"`
+}
+
+//LoadConfig loads client configuration from a configuration file profile and environment
+//variables, that take precedence over the file. Configuration file is read from a path given
+//in EQUINIX_CONFIG_FILE or from .equinix/config.json in user's home directory, if it exists.
+//Profile with a given name is used or, when name is empty, profile named in EQUINIX_PROFILE
+//or file's default profile. When no profile is selected and file has more than one profile,
+//configuration is loaded from environment variables only. Profile's base URL is either given explicitly as an endpoint
+//or determined by profile's environment, sandbox or production.
+//Production base URL is used when no base URL is configured
+func LoadConfig(profile string) (*Config, error) {
+	return loadConfig(profile, os.Getenv, defaultConfigFilePath)
+}
+
+//LoadConfigFile loads client configuration from a given profile of a given configuration file.
+//File's default profile is used when profile name is empty
+func LoadConfigFile(path string, profile string) (*Config, error) {
+	conf := &Config{}
+	if err := conf.readFile(path, profile); err != nil {
+		return nil, err
+	}
+	if conf.BaseURL == "" {
+		conf.BaseURL = environmentEndpoints[EnvironmentProduction]
+	}
+	return conf, nil
+}
+
+//ClientOptions returns options that create client according to configuration
+//with NewClientWithOptions. Options are returned only for configured values,
+//so options given before them are not reset
+func (c Config) ClientOptions() ([]ClientOption, error) {
+	var options []ClientOption
+	if c.Timeout > 0 {
+		options = append(options, WithTimeout(c.Timeout))
+	}
+	if c.PageSize > 0 {
+		options = append(options, WithPageSize(c.PageSize))
+	}
+	if c.UserAgentSuffix != "" {
+		options = append(options, WithUserAgentSuffix(c.UserAgentSuffix))
+	}
+	if len(c.Headers) > 0 {
+		options = append(options, WithHeaders(c.Headers))
+	}
+	if c.ClientID != "" || c.ClientSecret != "" {
+		options = append(options, WithCredentials(c.ClientID, c.ClientSecret))
+	}
+	if c.ProxyURL != "" {
+		proxyURL, err := url.Parse(c.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL: %w", err)
+		}
+		options = append(options, WithProxy(proxyURL))
+	}
+	if c.CAFile != "" {
+		pem, err := ioutil.ReadFile(c.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("CA file %s does not contain PEM encoded certificates", c.CAFile)
+		}
+		options = append(options, WithTLSConfig(&tls.Config{RootCAs: pool}))
+	}
+	return options, nil
+}
+
+//NewClientFromConfig creates new Equinix Fabric REST API client according to a given
+//configuration and additional options, i.e. interceptors
+func NewClientFromConfig(ctx context.Context, conf Config, options ...ClientOption) (*RestClient, error) {
+	confOptions, err := conf.ClientOptions()
+	if err != nil {
+		return nil, err
+	}
+	return NewClientWithOptions(ctx, conf.BaseURL, append(confOptions, options...)...)
+}
+
+func defaultConfigFilePath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	path := filepath.Join(home, ".equinix", "config.json")
+	if _, err := os.Stat(path); err != nil {
+		return ""
+	}
+	return path
+}
+
+func loadConfig(profile string, getenv func(string) string, defaultPath func() string) (*Config, error) {
+	conf := &Config{}
+	path := getenv(EnvConfigFile)
+	if path == "" {
+		path = defaultPath()
+	}
+	if profile == "" {
+		profile = getenv(EnvProfile)
+	}
+	if path != "" {
+		if err := conf.readFile(path, profile); err != nil && !errors.Is(err, errNoProfileSelected) {
+			return nil, err
+		}
+	} else if profile != "" {
+		return nil, fmt.Errorf("profile %q is requested but there is no configuration file", profile)
+	}
+	if err := conf.readEnv(getenv); err != nil {
+		return nil, err
+	}
+	if conf.BaseURL == "" {
+		conf.BaseURL = environmentEndpoints[EnvironmentProduction]
+	}
+	return conf, nil
+}
+
+func (c *Config) readFile(path string, profile string) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("cannot read configuration file: %w", err)
+	}
+	file := configFile{}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("cannot parse configuration file %s: %w", path, err)
+	}
+	if profile == "" {
+		profile = file.DefaultProfile
+	}
+	if profile == "" && len(file.Profiles) == 1 {
+		for name := range file.Profiles {
+			profile = name
+		}
+	}
+	if profile == "" {
+		return fmt.Errorf("configuration file %s has no default profile: %w", path, errNoProfileSelected)
+	}
+	fileProfile, ok := file.Profiles[profile]
+	if !ok {
+		return fmt.Errorf("profile %q not found in configuration file %s", profile, path)
+	}
+	c.Profile = profile
+	c.BaseURL = fileProfile.Endpoint
+	if c.BaseURL == "" && fileProfile.Environment != "" {
+		if c.BaseURL, ok = environmentEndpoints[fileProfile.Environment]; !ok {
+			return fmt.Errorf("profile %q has unknown environment %q", profile, fileProfile.Environment)
+		}
+	}
+	c.ClientID = fileProfile.ClientID
+	c.ClientSecret = fileProfile.ClientSecret
+	if fileProfile.Timeout != "" {
+		if c.Timeout, err = parseTimeout(fileProfile.Timeout); err != nil {
+			return fmt.Errorf("profile %q has invalid timeout: %w", profile, err)
+		}
+	}
+	c.PageSize = fileProfile.PageSize
+	c.UserAgentSuffix = fileProfile.UserAgent
+	c.Headers = fileProfile.Headers
+	c.ProxyURL = fileProfile.Proxy
+	c.CAFile = fileProfile.CAFile
+	return nil
+}
+
+func (c *Config) readEnv(getenv func(string) string) error {
+	setFromEnv := func(target *string, key string) {
+		if v := getenv(key); v != "" {
+			*target = v
+		}
+	}
+	setFromEnv(&c.BaseURL, EnvAPIEndpoint)
+	setFromEnv(&c.ClientID, EnvAPIClientID)
+	setFromEnv(&c.ClientSecret, EnvAPIClientSecret)
+	setFromEnv(&c.UserAgentSuffix, EnvAPIUserAgent)
+	setFromEnv(&c.ProxyURL, EnvAPIProxy)
+	setFromEnv(&c.CAFile, EnvAPICAFile)
+	if v := getenv(EnvAPITimeout); v != "" {
+		timeout, err := parseTimeout(v)
+		if err != nil {
+			return fmt.Errorf("invalid %s: %w", EnvAPITimeout, err)
+		}
+		c.Timeout = timeout
+	}
+	if v := getenv(EnvAPIPageSize); v != "" {
+		pageSize, err := strconv.Atoi(v)
+		if err != nil || pageSize < 1 {
+			return fmt.Errorf("invalid %s: %q is not a positive number", EnvAPIPageSize, v)
+		}
+		c.PageSize = pageSize
+	}
+	return nil
+}
+
+//parseTimeout parses timeout given either as a number of seconds or a duration
+func parseTimeout(v string) (time.Duration, error) {
+	if seconds, err := strconv.Atoi(v); err == nil {
+		return time.Duration(seconds) * time.Second, nil
+	}
+	return time.ParseDuration(v)
+}
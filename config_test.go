@@ -0,0 +1,206 @@
+package ecx
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testConfigFile = "./test-fixtures/ecx_config.json"
+
+func testEnv(env map[string]string) func(string) string {
+	return func(key string) string {
+		return env[key]
+	}
+}
+
+func noDefaultConfigFile() string {
+	return ""
+}
+
+func TestLoadConfig_defaultProfile(t *testing.T) {
+	//Given
+	env := testEnv(map[string]string{EnvConfigFile: testConfigFile})
+
+	//When
+	conf, err := loadConfig("", env, noDefaultConfigFile)
+
+	//Then
+	assert.Nil(t, err, "LoadConfig should not return an error")
+	assert.Equal(t, &Config{
+		Profile:         "sandbox",
+		BaseURL:         "https://sandboxapi.equinix.com",
+		ClientID:        "sandboxClientId",
+		ClientSecret:    "sandboxSecret",
+		Timeout:         90 * time.Second,
+		PageSize:        50,
+		UserAgentSuffix: "controller/1.0",
+		Headers:         map[string]string{"X-Correlation-Source": "controller"},
+	}, conf, "Config matches")
+}
+
+func TestLoadConfig_profile(t *testing.T) {
+	//Given
+	env := testEnv(map[string]string{
+		EnvConfigFile: testConfigFile,
+		EnvProfile:    "production",
+	})
+
+	//When
+	production, err := loadConfig("", env, noDefaultConfigFile)
+	onprem, onpremErr := loadConfig("onprem", env, noDefaultConfigFile)
+
+	//Then
+	assert.Nil(t, err, "LoadConfig should not return an error")
+	assert.Equal(t, "production", production.Profile, "Profile from environment is used")
+	assert.Equal(t, "https://api.equinix.com", production.BaseURL, "BaseURL is determined by environment")
+	assert.Equal(t, 30*time.Second, production.Timeout, "Timeout in seconds is parsed")
+	assert.Equal(t, "http://proxy.example.com:3128", production.ProxyURL, "ProxyURL matches")
+	assert.Nil(t, onpremErr, "LoadConfig should not return an error")
+	assert.Equal(t, "onprem", onprem.Profile, "Given profile takes precedence over environment")
+	assert.Equal(t, "https://fabric.example.com", onprem.BaseURL, "BaseURL is given endpoint")
+}
+
+func TestLoadConfig_envOverrides(t *testing.T) {
+	//Given
+	env := testEnv(map[string]string{
+		EnvConfigFile:      testConfigFile,
+		EnvAPIEndpoint:     "https://override.example.com",
+		EnvAPIClientID:     "envClientId",
+		EnvAPIClientSecret: "envSecret",
+		EnvAPITimeout:      "2m",
+		EnvAPIPageSize:     "20",
+	})
+
+	//When
+	conf, err := loadConfig("", env, noDefaultConfigFile)
+
+	//Then
+	assert.Nil(t, err, "LoadConfig should not return an error")
+	assert.Equal(t, "https://override.example.com", conf.BaseURL, "BaseURL is overridden")
+	assert.Equal(t, "envClientId", conf.ClientID, "ClientID is overridden")
+	assert.Equal(t, "envSecret", conf.ClientSecret, "ClientSecret is overridden")
+	assert.Equal(t, 2*time.Minute, conf.Timeout, "Timeout is overridden")
+	assert.Equal(t, 20, conf.PageSize, "PageSize is overridden")
+	assert.Equal(t, "controller/1.0", conf.UserAgentSuffix, "UserAgentSuffix from file is kept")
+}
+
+func TestLoadConfig_envOnly(t *testing.T) {
+	//Given
+	env := testEnv(map[string]string{
+		EnvAPIClientID:     "envClientId",
+		EnvAPIClientSecret: "envSecret",
+	})
+
+	//When
+	conf, err := loadConfig("", env, noDefaultConfigFile)
+
+	//Then
+	assert.Nil(t, err, "LoadConfig should not return an error")
+	assert.Equal(t, &Config{
+		BaseURL:      "https://api.equinix.com",
+		ClientID:     "envClientId",
+		ClientSecret: "envSecret",
+	}, conf, "Config matches")
+}
+
+func TestLoadConfig_envOnlyWithoutDefaultProfile(t *testing.T) {
+	//Given
+	env := testEnv(map[string]string{
+		EnvAPIClientID:     "envClientId",
+		EnvAPIClientSecret: "envSecret",
+	})
+	defaultPath := func() string {
+		return "./test-fixtures/ecx_config_no_default.json"
+	}
+
+	//When
+	conf, err := loadConfig("", env, defaultPath)
+	production, productionErr := loadConfig("production", env, defaultPath)
+
+	//Then
+	assert.Nil(t, err, "LoadConfig should not return an error")
+	assert.Equal(t, &Config{
+		BaseURL:      "https://api.equinix.com",
+		ClientID:     "envClientId",
+		ClientSecret: "envSecret",
+	}, conf, "Config matches")
+	assert.Nil(t, productionErr, "LoadConfig should not return an error")
+	assert.Equal(t, "production", production.Profile, "Given profile is used")
+}
+
+func TestLoadConfig_errors(t *testing.T) {
+	testCases := map[string]struct {
+		profile string
+		env     map[string]string
+	}{
+		"missing file":         {env: map[string]string{EnvConfigFile: "./test-fixtures/missing.json"}},
+		"missing profile":      {profile: "unknown", env: map[string]string{EnvConfigFile: testConfigFile}},
+		"unknown environment":  {profile: "invalid", env: map[string]string{EnvConfigFile: testConfigFile}},
+		"profile without file": {profile: "sandbox", env: map[string]string{}},
+		"invalid page size":    {env: map[string]string{EnvAPIPageSize: "many"}},
+		"invalid timeout":      {env: map[string]string{EnvAPITimeout: "soon"}},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			//When
+			conf, err := loadConfig(tc.profile, testEnv(tc.env), noDefaultConfigFile)
+
+			//Then
+			assert.NotNil(t, err, "LoadConfig should return an error")
+			assert.Nil(t, conf, "LoadConfig should not return configuration")
+		})
+	}
+}
+
+func TestLoadConfigFile(t *testing.T) {
+	//When
+	conf, err := LoadConfigFile(testConfigFile, "onprem")
+	noDefault, noDefaultErr := LoadConfigFile("./test-fixtures/ecx_config_no_default.json", "")
+
+	//Then
+	assert.Nil(t, err, "LoadConfigFile should not return an error")
+	assert.Equal(t, "onpremClientId", conf.ClientID, "ClientID matches")
+	assert.Equal(t, "https://fabric.example.com", conf.BaseURL, "BaseURL matches")
+	assert.NotNil(t, noDefaultErr, "LoadConfigFile should return an error when no profile is selected")
+	assert.Nil(t, noDefault, "LoadConfigFile should not return configuration")
+}
+
+func TestConfig_ClientOptions(t *testing.T) {
+	//Given
+	conf := Config{
+		Timeout:  time.Minute,
+		PageSize: 25,
+		ProxyURL: "http://proxy.example.com:3128",
+		ClientID: "clientId",
+	}
+
+	//When
+	options, err := conf.ClientOptions()
+	o := newClientOptions(options)
+
+	//Then
+	assert.Nil(t, err, "ClientOptions should not return an error")
+	assert.Equal(t, time.Minute, o.timeout, "Timeout matches")
+	assert.Equal(t, 25, o.pageSize, "PageSize matches")
+	assert.Equal(t, "http://proxy.example.com:3128", o.proxyURL.String(), "Proxy URL matches")
+	assert.Equal(t, "clientId", o.clientID, "ClientID matches")
+}
+
+func TestConfig_ClientOptions_unset(t *testing.T) {
+	//Given
+	conf := Config{BaseURL: "https://api.equinix.com"}
+	preceding := []ClientOption{WithTimeout(time.Minute), WithPageSize(25), WithUserAgentSuffix("app/1.0")}
+
+	//When
+	options, err := conf.ClientOptions()
+	o := newClientOptions(append(preceding, options...))
+
+	//Then
+	assert.Nil(t, err, "ClientOptions should not return an error")
+	assert.Empty(t, options, "No options are returned for unset values")
+	assert.Equal(t, time.Minute, o.timeout, "Preceding timeout is kept")
+	assert.Equal(t, 25, o.pageSize, "Preceding page size is kept")
+	assert.Equal(t, "app/1.0", o.userAgentSuffix, "Preceding user agent suffix is kept")
+}
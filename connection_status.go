@@ -0,0 +1,230 @@
+package ecx
+
+//ConnectionStatus describes status of a layer 2 connection, either on the buyer side
+//(L2Connection.Status) or on the provider side (L2Connection.ProviderStatus).
+//ConnectionStatus* constants can be used as ConnectionStatus values
+type ConnectionStatus string
+
+//ConnectionPhase describes overall lifecycle phase of a layer 2 connection derived from
+//its status, provider status and pending actions
+type ConnectionPhase string
+
+const (
+	//ConnectionPhaseProvisioning indicates that connection is being created
+	ConnectionPhaseProvisioning ConnectionPhase = "PROVISIONING"
+	//ConnectionPhaseAwaitingProvider indicates that connection awaits provider's approval
+	//or VLAN assignment
+	ConnectionPhaseAwaitingProvider ConnectionPhase = "AWAITING_PROVIDER"
+	//ConnectionPhaseActionRequired indicates that connection has pending actions that
+	//need to be completed by the buyer
+	ConnectionPhaseActionRequired ConnectionPhase = "ACTION_REQUIRED"
+	//ConnectionPhaseNeedsBGP indicates that connection awaits BGP peering configuration
+	ConnectionPhaseNeedsBGP ConnectionPhase = "NEEDS_BGP"
+	//ConnectionPhaseReady indicates that connection is established and can carry traffic
+	ConnectionPhaseReady ConnectionPhase = "READY"
+	//ConnectionPhaseDeprovisioning indicates that connection is being removed
+	ConnectionPhaseDeprovisioning ConnectionPhase = "DEPROVISIONING"
+	//ConnectionPhaseFailed indicates that connection was rejected
+	ConnectionPhaseFailed ConnectionPhase = "FAILED"
+	//ConnectionPhaseGone indicates that connection was removed
+	ConnectionPhaseGone ConnectionPhase = "GONE"
+	//ConnectionPhaseUnknown indicates that combination of connection statuses is not recognized
+	ConnectionPhaseUnknown ConnectionPhase = "UNKNOWN"
+)
+
+//connectionStatusTransitions describes allowed transitions of buyer side connection status
+var connectionStatusTransitions = map[ConnectionStatus][]ConnectionStatus{
+	ConnectionStatusProvisioning: {
+		ConnectionStatusProvisioned, ConnectionStatusPendingApproval, ConnectionStatusPendingAutoApproval,
+		ConnectionStatusPendingProviderVlan, ConnectionStatusPendingBGPPeering, ConnectionStatusRejected,
+		ConnectionStatusDeprovisioning, ConnectionStatusDeprovisioned,
+	},
+	ConnectionStatusPendingApproval: {
+		ConnectionStatusProvisioning, ConnectionStatusProvisioned, ConnectionStatusPendingProviderVlan,
+		ConnectionStatusPendingBGPPeering, ConnectionStatusRejected, ConnectionStatusDeprovisioning,
+	},
+	ConnectionStatusPendingAutoApproval: {
+		ConnectionStatusProvisioning, ConnectionStatusProvisioned, ConnectionStatusPendingProviderVlan,
+		ConnectionStatusPendingBGPPeering, ConnectionStatusRejected, ConnectionStatusDeprovisioning,
+	},
+	ConnectionStatusPendingProviderVlan: {
+		ConnectionStatusProvisioning, ConnectionStatusProvisioned, ConnectionStatusPendingBGPPeering,
+		ConnectionStatusRejected, ConnectionStatusDeprovisioning,
+	},
+	ConnectionStatusPendingBGPPeering: {
+		ConnectionStatusProvisioned, ConnectionStatusDeprovisioning,
+	},
+	ConnectionStatusProvisioned: {
+		ConnectionStatusProvisioning, ConnectionStatusPendingDelete, ConnectionStatusDeprovisioning,
+	},
+	ConnectionStatusPendingDelete: {
+		ConnectionStatusProvisioned, ConnectionStatusDeprovisioning, ConnectionStatusDeprovisioned,
+	},
+	ConnectionStatusDeprovisioning: {
+		ConnectionStatusDeprovisioned,
+	},
+	ConnectionStatusDeprovisioned: {
+		ConnectionStatusDeleted,
+	},
+	ConnectionStatusRejected: {
+		ConnectionStatusDeprovisioned, ConnectionStatusDeleted,
+	},
+	ConnectionStatusDeleted: {},
+}
+
+//providerStatusTransitions describes allowed transitions of provider side connection status
+var providerStatusTransitions = map[ConnectionStatus][]ConnectionStatus{
+	ConnectionStatusNotAvailable: {
+		ConnectionStatusPendingApproval, ConnectionStatusPendingAutoApproval, ConnectionStatusPendingProviderVlan,
+		ConnectionStatusProvisioning, ConnectionStatusProvisioned, ConnectionStatusAvailable,
+	},
+	ConnectionStatusPendingApproval: {
+		ConnectionStatusPendingProviderVlan, ConnectionStatusProvisioning, ConnectionStatusPendingBGPPeering,
+		ConnectionStatusProvisioned, ConnectionStatusAvailable, ConnectionStatusRejected, ConnectionStatusNotAvailable,
+	},
+	ConnectionStatusPendingAutoApproval: {
+		ConnectionStatusPendingProviderVlan, ConnectionStatusProvisioning, ConnectionStatusPendingBGPPeering,
+		ConnectionStatusProvisioned, ConnectionStatusAvailable, ConnectionStatusRejected, ConnectionStatusNotAvailable,
+	},
+	ConnectionStatusPendingProviderVlan: {
+		ConnectionStatusProvisioning, ConnectionStatusPendingBGPPeering, ConnectionStatusProvisioned,
+		ConnectionStatusAvailable, ConnectionStatusRejected,
+	},
+	ConnectionStatusProvisioning: {
+		ConnectionStatusPendingBGPPeering, ConnectionStatusProvisioned, ConnectionStatusAvailable,
+		ConnectionStatusRejected,
+	},
+	ConnectionStatusPendingBGPPeering: {
+		ConnectionStatusProvisioned, ConnectionStatusAvailable,
+	},
+	ConnectionStatusProvisioned: {
+		ConnectionStatusPendingDelete, ConnectionStatusDeprovisioning, ConnectionStatusDeprovisioned,
+		ConnectionStatusNotAvailable,
+	},
+	ConnectionStatusAvailable: {
+		ConnectionStatusPendingDelete, ConnectionStatusDeprovisioning, ConnectionStatusDeprovisioned,
+		ConnectionStatusNotAvailable,
+	},
+	ConnectionStatusPendingDelete: {
+		ConnectionStatusAvailable, ConnectionStatusProvisioned, ConnectionStatusDeprovisioning,
+		ConnectionStatusDeprovisioned,
+	},
+	ConnectionStatusDeprovisioning: {
+		ConnectionStatusDeprovisioned, ConnectionStatusNotAvailable,
+	},
+	ConnectionStatusDeprovisioned: {
+		ConnectionStatusDeleted, ConnectionStatusNotAvailable,
+	},
+	ConnectionStatusRejected: {
+		ConnectionStatusDeprovisioned, ConnectionStatusDeleted,
+	},
+	ConnectionStatusDeleted: {},
+}
+
+//IsTerminal checks if connection in a given status will not change its status anymore.
+//Only deleted connections are terminal, rejected and deprovisioned connections
+//still become deleted
+func (s ConnectionStatus) IsTerminal() bool {
+	return s == ConnectionStatusDeleted
+}
+
+//IsFailure checks if status indicates that connection could not be established
+func (s ConnectionStatus) IsFailure() bool {
+	return s == ConnectionStatusRejected
+}
+
+//IsPending checks if status indicates that connection is in transition,
+//awaiting approval, configuration or removal
+func (s ConnectionStatus) IsPending() bool {
+	switch s {
+	case ConnectionStatusPendingApproval, ConnectionStatusPendingAutoApproval, ConnectionStatusPendingProviderVlan,
+		ConnectionStatusPendingBGPPeering, ConnectionStatusProvisioning, ConnectionStatusPendingDelete,
+		ConnectionStatusDeprovisioning:
+		return true
+	}
+	return false
+}
+
+//IsActive checks if status indicates that connection is established
+func (s ConnectionStatus) IsActive() bool {
+	return s == ConnectionStatusProvisioned || s == ConnectionStatusAvailable
+}
+
+//IsAllowedStatusTransition checks if buyer side connection status (L2Connection.Status)
+//can change from one status to another. Staying in the same status is always allowed
+func IsAllowedStatusTransition(from ConnectionStatus, to ConnectionStatus) bool {
+	return isAllowedTransition(connectionStatusTransitions, from, to)
+}
+
+//IsAllowedProviderStatusTransition checks if provider side connection status
+//(L2Connection.ProviderStatus) can change from one status to another.
+//Staying in the same status is always allowed
+func IsAllowedProviderStatusTransition(from ConnectionStatus, to ConnectionStatus) bool {
+	return isAllowedTransition(providerStatusTransitions, from, to)
+}
+
+//AllowedStatusTransitions returns statuses that buyer side connection status
+//(L2Connection.Status) can change to from a given status
+func AllowedStatusTransitions(from ConnectionStatus) []ConnectionStatus {
+	return allowedTransitions(connectionStatusTransitions, from)
+}
+
+//AllowedProviderStatusTransitions returns statuses that provider side connection status
+//(L2Connection.ProviderStatus) can change to from a given status
+func AllowedProviderStatusTransitions(from ConnectionStatus) []ConnectionStatus {
+	return allowedTransitions(providerStatusTransitions, from)
+}
+
+//ClassifyL2Connection determines overall lifecycle phase of a given connection
+//from combination of its status, provider status and pending actions
+func ClassifyL2Connection(conn L2Connection) ConnectionPhase {
+	status := ConnectionStatus(StringValue(conn.Status))
+	providerStatus := ConnectionStatus(StringValue(conn.ProviderStatus))
+	switch {
+	case status == ConnectionStatusDeprovisioned || status == ConnectionStatusDeleted:
+		return ConnectionPhaseGone
+	case status.IsFailure() || providerStatus.IsFailure():
+		return ConnectionPhaseFailed
+	case status == ConnectionStatusPendingDelete || status == ConnectionStatusDeprovisioning ||
+		providerStatus == ConnectionStatusPendingDelete || providerStatus == ConnectionStatusDeprovisioning:
+		return ConnectionPhaseDeprovisioning
+	case len(conn.Actions) > 0:
+		return ConnectionPhaseActionRequired
+	case status == ConnectionStatusPendingBGPPeering || providerStatus == ConnectionStatusPendingBGPPeering:
+		return ConnectionPhaseNeedsBGP
+	case isAwaitingProvider(status) || isAwaitingProvider(providerStatus):
+		return ConnectionPhaseAwaitingProvider
+	case status == ConnectionStatusProvisioned && (providerStatus == "" || providerStatus.IsActive() ||
+		providerStatus == ConnectionStatusNotAvailable):
+		return ConnectionPhaseReady
+	case status == ConnectionStatusProvisioning || status == ConnectionStatusProvisioned:
+		return ConnectionPhaseProvisioning
+	}
+	return ConnectionPhaseUnknown
+}
+
+func isAwaitingProvider(s ConnectionStatus) bool {
+	switch s {
+	case ConnectionStatusPendingApproval, ConnectionStatusPendingAutoApproval, ConnectionStatusPendingProviderVlan:
+		return true
+	}
+	return false
+}
+
+func isAllowedTransition(transitions map[ConnectionStatus][]ConnectionStatus, from ConnectionStatus, to ConnectionStatus) bool {
+	if from == to {
+		return true
+	}
+	for _, allowed := range transitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+func allowedTransitions(transitions map[ConnectionStatus][]ConnectionStatus, from ConnectionStatus) []ConnectionStatus {
+	allowed := make([]ConnectionStatus, len(transitions[from]))
+	copy(allowed, transitions[from])
+	return allowed
+}
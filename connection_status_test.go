@@ -0,0 +1,114 @@
+package ecx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConnectionStatus_Classification(t *testing.T) {
+	//given
+	input := []ConnectionStatus{
+		ConnectionStatusProvisioned,
+		ConnectionStatusAvailable,
+		ConnectionStatusPendingBGPPeering,
+		ConnectionStatusRejected,
+		ConnectionStatusDeleted,
+		ConnectionStatusNotAvailable,
+	}
+	expectedTerminal := []bool{false, false, false, false, true, false}
+	expectedFailure := []bool{false, false, false, true, false, false}
+	expectedPending := []bool{false, false, true, false, false, false}
+	expectedActive := []bool{true, true, false, false, false, false}
+	//when
+	terminal := make([]bool, len(input))
+	failure := make([]bool, len(input))
+	pending := make([]bool, len(input))
+	active := make([]bool, len(input))
+	for i := range input {
+		terminal[i] = input[i].IsTerminal()
+		failure[i] = input[i].IsFailure()
+		pending[i] = input[i].IsPending()
+		active[i] = input[i].IsActive()
+	}
+	//then
+	assert.Equal(t, expectedTerminal, terminal, "IsTerminal output matches expected output")
+	assert.Equal(t, expectedFailure, failure, "IsFailure output matches expected output")
+	assert.Equal(t, expectedPending, pending, "IsPending output matches expected output")
+	assert.Equal(t, expectedActive, active, "IsActive output matches expected output")
+}
+
+func TestIsAllowedStatusTransition(t *testing.T) {
+	assert.True(t, IsAllowedStatusTransition(ConnectionStatusProvisioning, ConnectionStatusProvisioned), "Provisioning can become provisioned")
+	assert.True(t, IsAllowedStatusTransition(ConnectionStatusProvisioned, ConnectionStatusProvisioned), "Status can remain the same")
+	assert.True(t, IsAllowedStatusTransition(ConnectionStatusDeprovisioned, ConnectionStatusDeleted), "Deprovisioned can become deleted")
+	assert.False(t, IsAllowedStatusTransition(ConnectionStatusDeleted, ConnectionStatusProvisioning), "Deleted is final")
+	assert.False(t, IsAllowedStatusTransition(ConnectionStatusProvisioned, ConnectionStatusAvailable), "Available is provider status only")
+}
+
+func TestIsAllowedProviderStatusTransition(t *testing.T) {
+	assert.True(t, IsAllowedProviderStatusTransition(ConnectionStatusNotAvailable, ConnectionStatusPendingApproval), "Not available can become pending approval")
+	assert.True(t, IsAllowedProviderStatusTransition(ConnectionStatusPendingApproval, ConnectionStatusRejected), "Pending approval can become rejected")
+	assert.True(t, IsAllowedProviderStatusTransition(ConnectionStatusPendingBGPPeering, ConnectionStatusAvailable), "Pending BGP peering can become available")
+	assert.False(t, IsAllowedProviderStatusTransition(ConnectionStatusRejected, ConnectionStatusAvailable), "Rejected cannot become available")
+}
+
+func TestAllowedStatusTransitions(t *testing.T) {
+	assert.Equal(t, []ConnectionStatus{ConnectionStatusDeprovisioned, ConnectionStatusDeleted},
+		AllowedStatusTransitions(ConnectionStatusRejected), "Rejected can become deprovisioned or deleted")
+	assert.Empty(t, AllowedStatusTransitions(ConnectionStatusDeleted), "Deleted has no transitions")
+	assert.Empty(t, AllowedProviderStatusTransitions("UNKNOWN"), "Unknown status has no transitions")
+}
+
+func TestConnectionStatus_terminalHasNoTransitions(t *testing.T) {
+	for _, transitions := range []map[ConnectionStatus][]ConnectionStatus{connectionStatusTransitions, providerStatusTransitions} {
+		for from := range transitions {
+			if from.IsTerminal() {
+				assert.Empty(t, transitions[from], "Terminal status %s has no transitions", from)
+			}
+			for _, to := range transitions[from] {
+				if to.IsTerminal() {
+					continue
+				}
+				_, ok := transitions[to]
+				assert.True(t, ok, "Non-terminal status %s reachable from %s has transitions", to, from)
+			}
+		}
+	}
+}
+
+func TestClassifyL2Connection(t *testing.T) {
+	//given
+	input := []L2Connection{
+		{Status: String(ConnectionStatusProvisioned), ProviderStatus: String(ConnectionStatusAvailable)},
+		{Status: String(ConnectionStatusProvisioned), ProviderStatus: String(ConnectionStatusNotAvailable)},
+		{Status: String(ConnectionStatusProvisioned), ProviderStatus: String(ConnectionStatusPendingApproval)},
+		{Status: String(ConnectionStatusProvisioned), ProviderStatus: String(ConnectionStatusPendingBGPPeering)},
+		{Status: String(ConnectionStatusProvisioned), ProviderStatus: String(ConnectionStatusPendingApproval),
+			Actions: []L2ConnectionAction{{OperationID: String("CONFIRM_CONNECTION")}}},
+		{Status: String(ConnectionStatusProvisioning), ProviderStatus: String(ConnectionStatusNotAvailable)},
+		{Status: String(ConnectionStatusPendingDelete), ProviderStatus: String(ConnectionStatusAvailable)},
+		{Status: String(ConnectionStatusProvisioned), ProviderStatus: String(ConnectionStatusRejected)},
+		{Status: String(ConnectionStatusDeprovisioned), ProviderStatus: String(ConnectionStatusDeprovisioned)},
+		{},
+	}
+	expected := []ConnectionPhase{
+		ConnectionPhaseReady,
+		ConnectionPhaseReady,
+		ConnectionPhaseAwaitingProvider,
+		ConnectionPhaseNeedsBGP,
+		ConnectionPhaseActionRequired,
+		ConnectionPhaseProvisioning,
+		ConnectionPhaseDeprovisioning,
+		ConnectionPhaseFailed,
+		ConnectionPhaseGone,
+		ConnectionPhaseUnknown,
+	}
+	//when
+	output := make([]ConnectionPhase, len(input))
+	for i := range input {
+		output[i] = ClassifyL2Connection(input[i])
+	}
+	//then
+	assert.Equal(t, expected, output, "Output matches expected output")
+}
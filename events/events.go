@@ -0,0 +1,261 @@
+//Package events implements receiver of Equinix Fabric event notifications
+package events
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/equinix/ecx-go/v2"
+	"github.com/equinix/ecx-go/v2/internal/api"
+)
+
+const (
+	//SignatureHeader is a name of HTTP header with hex encoded HMAC-SHA256 signature
+	//of notification body, computed with subscription's secret
+	SignatureHeader = "X-Equinix-Signature"
+	//EventTypeConnectionCreated indicates that layer 2 connection was created
+	EventTypeConnectionCreated EventType = "L2_CONNECTION_CREATED"
+	//EventTypeConnectionStatusChanged indicates that layer 2 connection status has changed
+	EventTypeConnectionStatusChanged EventType = "L2_CONNECTION_STATUS_CHANGED"
+	//EventTypeConnectionProviderStatusChanged indicates that layer 2 connection provider status has changed
+	EventTypeConnectionProviderStatusChanged EventType = "L2_CONNECTION_PROVIDER_STATUS_CHANGED"
+	//EventTypeConnectionDeleted indicates that layer 2 connection was deleted
+	EventTypeConnectionDeleted EventType = "L2_CONNECTION_DELETED"
+
+	defaultDeduplicationSize = 1000
+	maxBodySize              = 1 << 20
+)
+
+//EventType describes type of Equinix Fabric event
+type EventType string
+
+//Event describes Equinix Fabric event notification related to a layer 2 connection
+type Event struct {
+	ID                     string
+	Type                   EventType
+	Time                   time.Time
+	ConnectionUUID         string
+	ConnectionName         string
+	Status                 ecx.ConnectionStatus
+	PreviousStatus         ecx.ConnectionStatus
+	ProviderStatus         ecx.ConnectionStatus
+	PreviousProviderStatus ecx.ConnectionStatus
+	Message                string
+}
+
+//HandlerFunc processes event notification. Returned error causes notification
+//to be rejected, so it can be redelivered
+type HandlerFunc func(ctx context.Context, event Event) error
+
+//Handler is http.Handler that verifies signatures of event notifications,
+//parses them, drops duplicates and dispatches events to registered handlers
+type Handler struct {
+	secret      []byte
+	mu          sync.RWMutex
+	handlers    map[EventType][]HandlerFunc
+	anyHandlers []HandlerFunc
+	seen        *idCache
+}
+
+//NewHandler creates new event notification handler that verifies notifications
+//with a given subscription secret. Error is returned when secret is empty
+func NewHandler(secret string) (*Handler, error) {
+	if secret == "" {
+		return nil, fmt.Errorf("subscription secret is required to verify notifications")
+	}
+	return newHandler([]byte(secret)), nil
+}
+
+//NewInsecureHandler creates new event notification handler that does not verify
+//notification signatures. It should be used only for testing
+func NewInsecureHandler() *Handler {
+	return newHandler(nil)
+}
+
+func newHandler(secret []byte) *Handler {
+	return &Handler{
+		secret:   secret,
+		handlers: make(map[EventType][]HandlerFunc),
+		seen:     newIDCache(defaultDeduplicationSize),
+	}
+}
+
+//WithDeduplicationSize sets number of most recent event IDs remembered to drop duplicates
+func (h *Handler) WithDeduplicationSize(size int) *Handler {
+	h.seen = newIDCache(size)
+	return h
+}
+
+//On registers function that handles events of a given type
+func (h *Handler) On(eventType EventType, fn HandlerFunc) *Handler {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.handlers[eventType] = append(h.handlers[eventType], fn)
+	return h
+}
+
+//OnAny registers function that handles events of all types
+func (h *Handler) OnAny(fn HandlerFunc) *Handler {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.anyHandlers = append(h.anyHandlers, fn)
+	return h
+}
+
+//ServeHTTP handles event notification request
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+	if err != nil {
+		http.Error(w, "cannot read request body", http.StatusBadRequest)
+		return
+	}
+	if !h.verify(body, r.Header.Get(SignatureHeader)) {
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+	event, err := Parse(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !h.seen.addIfAbsent(event.ID) {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	if err := h.dispatch(r.Context(), *event); err != nil {
+		h.seen.remove(event.ID)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+//Parse parses event notification payload
+func Parse(payload []byte) (*Event, error) {
+	apiEvent := api.Event{}
+	if err := json.Unmarshal(payload, &apiEvent); err != nil {
+		return nil, fmt.Errorf("cannot parse event: %s", err)
+	}
+	if ecx.StringValue(apiEvent.ID) == "" {
+		return nil, fmt.Errorf("cannot parse event: missing event identifier")
+	}
+	if ecx.StringValue(apiEvent.Type) == "" {
+		return nil, fmt.Errorf("cannot parse event: missing event type")
+	}
+	return mapEventAPIToDomain(apiEvent), nil
+}
+
+//Sign computes signature of a given payload with a given secret, as sent in SignatureHeader
+func Sign(payload []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (h *Handler) verify(body []byte, signature string) bool {
+	if len(h.secret) == 0 {
+		return true
+	}
+	received, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, h.secret)
+	mac.Write(body)
+	return hmac.Equal(received, mac.Sum(nil))
+}
+
+func (h *Handler) dispatch(ctx context.Context, event Event) error {
+	h.mu.RLock()
+	handlers := append(append([]HandlerFunc{}, h.handlers[event.Type]...), h.anyHandlers...)
+	h.mu.RUnlock()
+	for _, fn := range handlers {
+		if err := fn(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func mapEventAPIToDomain(apiEvent api.Event) *Event {
+	event := &Event{
+		ID:   ecx.StringValue(apiEvent.ID),
+		Type: EventType(ecx.StringValue(apiEvent.Type)),
+	}
+	if t, err := time.Parse(time.RFC3339, ecx.StringValue(apiEvent.Time)); err == nil {
+		event.Time = t
+	}
+	if apiEvent.Data != nil {
+		event.ConnectionUUID = ecx.StringValue(apiEvent.Data.ConnectionUUID)
+		event.ConnectionName = ecx.StringValue(apiEvent.Data.ConnectionName)
+		event.Status = ecx.ConnectionStatus(ecx.StringValue(apiEvent.Data.Status))
+		event.PreviousStatus = ecx.ConnectionStatus(ecx.StringValue(apiEvent.Data.PreviousStatus))
+		event.ProviderStatus = ecx.ConnectionStatus(ecx.StringValue(apiEvent.Data.ProviderStatus))
+		event.PreviousProviderStatus = ecx.ConnectionStatus(ecx.StringValue(apiEvent.Data.PreviousProviderStatus))
+		event.Message = ecx.StringValue(apiEvent.Data.Message)
+	}
+	return event
+}
+
+//idCache remembers limited number of most recently added identifiers
+type idCache struct {
+	mu    sync.Mutex
+	size  int
+	ids   map[string]struct{}
+	order []string
+}
+
+func newIDCache(size int) *idCache {
+	return &idCache{
+		size: size,
+		ids:  make(map[string]struct{}, size),
+	}
+}
+
+//addIfAbsent adds given identifier unless it is already present.
+//Returns true if identifier was added
+func (c *idCache) addIfAbsent(id string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.ids[id]; ok {
+		return false
+	}
+	if c.size <= 0 {
+		return true
+	}
+	if len(c.order) >= c.size {
+		delete(c.ids, c.order[0])
+		c.order = c.order[1:]
+	}
+	c.ids[id] = struct{}{}
+	c.order = append(c.order, id)
+	return true
+}
+
+func (c *idCache) remove(id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.ids[id]; !ok {
+		return
+	}
+	delete(c.ids, id)
+	for i := range c.order {
+		if c.order[i] == id {
+			c.order = append(c.order[:i], c.order[i+1:]...)
+			break
+		}
+	}
+}
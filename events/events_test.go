@@ -0,0 +1,270 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/equinix/ecx-go/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+const testSecret = "secret"
+
+func newTestHandler(t *testing.T) *Handler {
+	handler, err := NewHandler(testSecret)
+	if err != nil {
+		assert.Failf(t, "Cannot create handler due to %s", err.Error())
+	}
+	return handler
+}
+
+func readEventPayload(t *testing.T) []byte {
+	payload, err := ioutil.ReadFile("../test-fixtures/ecx_event_connection_status_changed.json")
+	if err != nil {
+		assert.Failf(t, "Cannot read test payload due to %s", err.Error())
+	}
+	return payload
+}
+
+func postEvent(handler http.Handler, payload []byte, signature string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/events", bytes.NewReader(payload))
+	req.Header.Set(SignatureHeader, signature)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestHandler_dispatch(t *testing.T) {
+	//Given
+	payload := readEventPayload(t)
+	var received []Event
+	var anyCalls int
+	handler := newTestHandler(t).
+		On(EventTypeConnectionStatusChanged, func(ctx context.Context, event Event) error {
+			received = append(received, event)
+			return nil
+		}).
+		On(EventTypeConnectionDeleted, func(ctx context.Context, event Event) error {
+			assert.Fail(t, "Handler for other event type should not be called")
+			return nil
+		}).
+		OnAny(func(ctx context.Context, event Event) error {
+			anyCalls++
+			return nil
+		})
+
+	//When
+	rec := postEvent(handler, payload, Sign(payload, testSecret))
+
+	//Then
+	assert.Equal(t, http.StatusOK, rec.Code, "Response code matches")
+	assert.Equal(t, 1, anyCalls, "Handler for any event type was called")
+	assert.Equal(t, 1, len(received), "Handler for event type was called")
+	event := received[0]
+	assert.Equal(t, "7f3e2d1c-0b9a-4887-a6b5-c4d3e2f1a0b9", event.ID, "ID matches")
+	assert.Equal(t, EventTypeConnectionStatusChanged, event.Type, "Type matches")
+	assert.Equal(t, time.Date(2020, 11, 5, 10, 15, 30, 0, time.UTC), event.Time.UTC(), "Time matches")
+	assert.Equal(t, "0f2a4b8c-4fe7-11eb-ae93-0242ac130002", event.ConnectionUUID, "ConnectionUUID matches")
+	assert.Equal(t, "tf-conn-test", event.ConnectionName, "ConnectionName matches")
+	assert.Equal(t, ecx.ConnectionStatus(ecx.ConnectionStatusProvisioned), event.Status, "Status matches")
+	assert.Equal(t, ecx.ConnectionStatus(ecx.ConnectionStatusProvisioning), event.PreviousStatus, "PreviousStatus matches")
+	assert.Equal(t, ecx.ConnectionStatus(ecx.ConnectionStatusAvailable), event.ProviderStatus, "ProviderStatus matches")
+	assert.Equal(t, ecx.ConnectionStatus(ecx.ConnectionStatusPendingApproval), event.PreviousProviderStatus, "PreviousProviderStatus matches")
+	assert.Equal(t, "Connection provisioned", event.Message, "Message matches")
+}
+
+func TestHandler_duplicate(t *testing.T) {
+	//Given
+	payload := readEventPayload(t)
+	calls := 0
+	handler := newTestHandler(t).OnAny(func(ctx context.Context, event Event) error {
+		calls++
+		return nil
+	})
+
+	//When
+	first := postEvent(handler, payload, Sign(payload, testSecret))
+	second := postEvent(handler, payload, Sign(payload, testSecret))
+
+	//Then
+	assert.Equal(t, http.StatusOK, first.Code, "First response code matches")
+	assert.Equal(t, http.StatusOK, second.Code, "Duplicate response code matches")
+	assert.Equal(t, 1, calls, "Duplicate event is not dispatched")
+}
+
+func TestHandler_handlerError(t *testing.T) {
+	//Given
+	payload := readEventPayload(t)
+	calls := 0
+	handler := newTestHandler(t).OnAny(func(ctx context.Context, event Event) error {
+		calls++
+		if calls == 1 {
+			return errors.New("temporary failure")
+		}
+		return nil
+	})
+
+	//When
+	first := postEvent(handler, payload, Sign(payload, testSecret))
+	second := postEvent(handler, payload, Sign(payload, testSecret))
+
+	//Then
+	assert.Equal(t, http.StatusInternalServerError, first.Code, "Failed event response code matches")
+	assert.Equal(t, http.StatusOK, second.Code, "Redelivered event response code matches")
+	assert.Equal(t, 2, calls, "Redelivered event is dispatched again")
+}
+
+func TestHandler_concurrentDuplicates(t *testing.T) {
+	//Given
+	payload := readEventPayload(t)
+	var calls int32
+	release := make(chan struct{})
+	handler := newTestHandler(t).OnAny(func(ctx context.Context, event Event) error {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return nil
+	})
+	deliveries := 10
+	codes := make(chan int, deliveries)
+
+	//When
+	var wg sync.WaitGroup
+	for i := 0; i < deliveries; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			codes <- postEvent(handler, payload, Sign(payload, testSecret)).Code
+		}()
+	}
+	for i := 0; i < deliveries-1; i++ {
+		assert.Equal(t, http.StatusOK, <-codes, "Duplicate response code matches")
+	}
+	close(release)
+	wg.Wait()
+
+	//Then
+	assert.Equal(t, http.StatusOK, <-codes, "Response code matches")
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls), "Concurrent duplicates are dispatched once")
+}
+
+func TestNewHandler_emptySecret(t *testing.T) {
+	//When
+	handler, err := NewHandler("")
+
+	//Then
+	assert.NotNil(t, err, "NewHandler should return an error")
+	assert.Nil(t, handler, "NewHandler should not return a handler")
+}
+
+func TestNewInsecureHandler(t *testing.T) {
+	//Given
+	payload := readEventPayload(t)
+	calls := 0
+	handler := NewInsecureHandler().OnAny(func(ctx context.Context, event Event) error {
+		calls++
+		return nil
+	})
+
+	//When
+	rec := postEvent(handler, payload, "")
+
+	//Then
+	assert.Equal(t, http.StatusOK, rec.Code, "Response code matches")
+	assert.Equal(t, 1, calls, "Unsigned event is dispatched")
+}
+
+func TestHandler_invalidSignature(t *testing.T) {
+	//Given
+	payload := readEventPayload(t)
+	handler := newTestHandler(t).OnAny(func(ctx context.Context, event Event) error {
+		assert.Fail(t, "Handler should not be called")
+		return nil
+	})
+
+	//When
+	wrongSecret := postEvent(handler, payload, Sign(payload, "other"))
+	notHex := postEvent(handler, payload, "signature")
+
+	//Then
+	assert.Equal(t, http.StatusUnauthorized, wrongSecret.Code, "Response code for wrong secret matches")
+	assert.Equal(t, http.StatusUnauthorized, notHex.Code, "Response code for malformed signature matches")
+}
+
+func TestHandler_invalidPayload(t *testing.T) {
+	//Given
+	handler := newTestHandler(t)
+	malformed := []byte(`{"id":`)
+	missingID := []byte(`{"type":"L2_CONNECTION_CREATED"}`)
+
+	//When
+	malformedRec := postEvent(handler, malformed, Sign(malformed, testSecret))
+	missingIDRec := postEvent(handler, missingID, Sign(missingID, testSecret))
+
+	//Then
+	assert.Equal(t, http.StatusBadRequest, malformedRec.Code, "Response code for malformed payload matches")
+	assert.Equal(t, http.StatusBadRequest, missingIDRec.Code, "Response code for payload without ID matches")
+}
+
+func TestHandler_method(t *testing.T) {
+	//Given
+	handler := newTestHandler(t)
+	req := httptest.NewRequest(http.MethodGet, "/events", nil)
+	rec := httptest.NewRecorder()
+
+	//When
+	handler.ServeHTTP(rec, req)
+
+	//Then
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code, "Response code matches")
+	assert.Equal(t, http.MethodPost, rec.Header().Get("Allow"), "Allow header matches")
+}
+
+func TestHandler_deduplicationSize(t *testing.T) {
+	//Given
+	calls := 0
+	handler := NewInsecureHandler().WithDeduplicationSize(1).OnAny(func(ctx context.Context, event Event) error {
+		calls++
+		return nil
+	})
+	first := []byte(`{"id":"1","type":"L2_CONNECTION_CREATED"}`)
+	second := []byte(`{"id":"2","type":"L2_CONNECTION_CREATED"}`)
+
+	//When
+	postEvent(handler, first, "")
+	postEvent(handler, second, "")
+	postEvent(handler, first, "")
+
+	//Then
+	assert.Equal(t, 3, calls, "Evicted event ID is dispatched again")
+}
+
+func TestHandler_httptestServer(t *testing.T) {
+	//Given
+	payload := readEventPayload(t)
+	received := make(chan Event, 1)
+	server := httptest.NewServer(newTestHandler(t).OnAny(func(ctx context.Context, event Event) error {
+		received <- event
+		return nil
+	}))
+	defer server.Close()
+	req, _ := http.NewRequest(http.MethodPost, server.URL, bytes.NewReader(payload))
+	req.Header.Set(SignatureHeader, Sign(payload, testSecret))
+
+	//When
+	resp, err := server.Client().Do(req)
+
+	//Then
+	assert.Nil(t, err, "Request should not fail")
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "Response code matches")
+	event := <-received
+	assert.Equal(t, EventTypeConnectionStatusChanged, event.Type, "Type matches")
+}
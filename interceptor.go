@@ -0,0 +1,75 @@
+package ecx
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"golang.org/x/time/rate"
+)
+
+//Invocation describes single Equinix Fabric API request made by RestClient operation.
+//Interceptors may modify invocation before passing it to the next invoker, i.e. add
+//headers or replace request body, or complete it without calling next invoker,
+//i.e. by decoding cached response body into Result
+type Invocation struct {
+	//Context is a context of client operation. Carries operation's trace span
+	//when client is traced. Request is sent in this context, so interceptors may
+	//replace it, i.e. to apply a deadline
+	Context context.Context
+	//Operation is a name of client operation, i.e. GetL2Connection
+	Operation string
+	//Method is HTTP method of a request
+	Method string
+	//Path is API path of a request, i.e. /ecx/v3/l2/connections/a8ba52de
+	Path string
+	//PathTemplate is API path template without resource identifiers,
+	//i.e. /ecx/v3/l2/connections/{connectionUUID}
+	PathTemplate string
+	//Query is a set of request query parameters
+	Query url.Values
+	//Header is a set of request headers
+	Header http.Header
+	//Body is request body or nil when request has no body
+	Body interface{}
+	//Result is a pointer to structure that response body is decoded to,
+	//or nil when response body is not used
+	Result interface{}
+	//StatusCode is HTTP status code of a response, set once request was executed.
+	//Status code is zero when no HTTP response was received
+	StatusCode int
+}
+
+//Invoker executes API request described by given invocation
+type Invoker func(inv *Invocation) error
+
+//Interceptor wraps invoker with a cross-cutting behavior, i.e. logging, retries or
+//authentication, and returns resulting invoker. Interceptor should call next invoker
+//to proceed with the request
+type Interceptor func(next Invoker) Invoker
+
+//RateLimitInterceptor creates interceptor that delays API requests according to a given
+//rate limiter. Limiter may be shared by many clients, i.e. ones that use the same
+//application credentials
+func RateLimitInterceptor(limiter *rate.Limiter) Interceptor {
+	return func(next Invoker) Invoker {
+		return func(inv *Invocation) error {
+			ctx := inv.Context
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			if err := limiter.Wait(ctx); err != nil {
+				return err
+			}
+			return next(inv)
+		}
+	}
+}
+
+//chain wraps given invoker with client's interceptors
+func (c RestClient) chain(invoker Invoker) Invoker {
+	for i := len(c.interceptors) - 1; i >= 0; i-- {
+		invoker = c.interceptors[i](invoker)
+	}
+	return invoker
+}
@@ -0,0 +1,214 @@
+package ecx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/equinix/ecx-go/v2/internal/api"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInterceptors_chain(t *testing.T) {
+	//Given
+	connID := "connId"
+	var authorization string
+	testHc := &http.Client{}
+	httpmock.ActivateNonDefault(testHc)
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ecx/v3/l2/connections/%s", baseURL, connID),
+		func(r *http.Request) (*http.Response, error) {
+			authorization = r.Header.Get("Authorization")
+			return httpmock.NewJsonResponse(200, api.L2ConnectionResponse{UUID: String(connID)})
+		},
+	)
+	defer httpmock.DeactivateAndReset()
+	var calls []string
+	var observed Invocation
+	logging := func(next Invoker) Invoker {
+		return func(inv *Invocation) error {
+			calls = append(calls, "logging")
+			err := next(inv)
+			observed = *inv
+			return err
+		}
+	}
+	auth := func(next Invoker) Invoker {
+		return func(inv *Invocation) error {
+			calls = append(calls, "auth")
+			inv.Header.Set("Authorization", "Bearer token")
+			return next(inv)
+		}
+	}
+	ecxClient := NewClient(context.Background(), baseURL, testHc, WithInterceptors(logging, auth))
+
+	//When
+	conn, err := ecxClient.GetL2Connection(connID)
+
+	//Then
+	assert.Nil(t, err, "Client should not return an error")
+	assert.Equal(t, connID, StringValue(conn.UUID), "Connection UUID matches")
+	assert.Equal(t, []string{"logging", "auth"}, calls, "Interceptors are called in order")
+	assert.Equal(t, "Bearer token", authorization, "Header set by interceptor is sent")
+	assert.Equal(t, "GetL2Connection", observed.Operation, "Operation matches")
+	assert.Equal(t, http.MethodGet, observed.Method, "Method matches")
+	assert.Equal(t, "/ecx/v3/l2/connections/"+connID, observed.Path, "Path matches")
+	assert.Equal(t, "/ecx/v3/l2/connections/{connectionUUID}", observed.PathTemplate, "Path template matches")
+	assert.IsType(t, &api.L2ConnectionResponse{}, observed.Result, "Result target matches")
+	assert.Equal(t, 200, observed.StatusCode, "Status code matches")
+}
+
+func TestInterceptors_requestBody(t *testing.T) {
+	//Given
+	testHc := &http.Client{}
+	httpmock.ActivateNonDefault(testHc)
+	reqBody := api.L2ConnectionRequest{}
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/ecx/v3/l2/connections", baseURL),
+		func(r *http.Request) (*http.Response, error) {
+			if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+				return httpmock.NewStringResponse(400, ""), nil
+			}
+			return httpmock.NewJsonResponse(200, api.CreateL2ConnectionResponse{PrimaryConnectionID: String("connId")})
+		},
+	)
+	defer httpmock.DeactivateAndReset()
+	var body interface{}
+	recordBody := func(next Invoker) Invoker {
+		return func(inv *Invocation) error {
+			body = inv.Body
+			return next(inv)
+		}
+	}
+	ecxClient := NewClient(context.Background(), baseURL, testHc, WithInterceptors(recordBody))
+
+	//When
+	uuid, err := ecxClient.CreateL2Connection(L2Connection{Name: String("conn"), PortUUID: String("portId")})
+
+	//Then
+	assert.Nil(t, err, "Client should not return an error")
+	assert.Equal(t, "connId", StringValue(uuid), "Connection UUID matches")
+	assert.IsType(t, &api.L2ConnectionRequest{}, body, "Request body matches")
+	assert.Equal(t, "conn", StringValue(reqBody.PrimaryName), "Request body is sent")
+}
+
+func TestInterceptors_retry(t *testing.T) {
+	//Given
+	attempts := 0
+	testHc := &http.Client{}
+	httpmock.ActivateNonDefault(testHc)
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ecx/v3/port/userport", baseURL),
+		func(r *http.Request) (*http.Response, error) {
+			attempts++
+			if attempts == 1 {
+				return httpmock.NewStringResponse(503, ""), nil
+			}
+			return httpmock.NewJsonResponse(200, []api.Port{{UUID: String("portId")}})
+		},
+	)
+	defer httpmock.DeactivateAndReset()
+	retry := func(next Invoker) Invoker {
+		return func(inv *Invocation) error {
+			err := next(inv)
+			if inv.StatusCode == http.StatusServiceUnavailable {
+				err = next(inv)
+			}
+			return err
+		}
+	}
+	ecxClient := NewClient(context.Background(), baseURL, testHc, WithInterceptors(retry))
+
+	//When
+	ports, err := ecxClient.GetUserPorts()
+
+	//Then
+	assert.Nil(t, err, "Client should not return an error")
+	assert.Equal(t, 2, attempts, "Request is retried")
+	assert.Equal(t, 1, len(ports), "Ports are returned")
+}
+
+func TestInterceptors_context(t *testing.T) {
+	//Given
+	deadlines := make(chan time.Time, 1)
+	testHc := &http.Client{}
+	httpmock.ActivateNonDefault(testHc)
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ecx/v3/port/userport", baseURL),
+		func(r *http.Request) (*http.Response, error) {
+			deadline, _ := r.Context().Deadline()
+			deadlines <- deadline
+			return httpmock.NewStringResponse(200, "[]"), nil
+		},
+	)
+	defer httpmock.DeactivateAndReset()
+	deadline := time.Now().Add(time.Hour)
+	withDeadline := func(next Invoker) Invoker {
+		return func(inv *Invocation) error {
+			ctx, cancel := context.WithDeadline(inv.Context, deadline)
+			defer cancel()
+			inv.Context = ctx
+			return next(inv)
+		}
+	}
+	ecxClient := NewClient(context.Background(), baseURL, testHc, WithInterceptors(withDeadline))
+
+	//When
+	_, err := ecxClient.GetUserPorts()
+
+	//Then
+	assert.Nil(t, err, "Client should not return an error")
+	select {
+	case reqDeadline := <-deadlines:
+		assert.True(t, deadline.Equal(reqDeadline), "Request is sent in interceptor's context")
+	default:
+		assert.Fail(t, "Request was not sent")
+	}
+}
+
+func TestInterceptors_shortCircuit(t *testing.T) {
+	//Given
+	testHc := &http.Client{}
+	httpmock.ActivateNonDefault(testHc)
+	defer httpmock.DeactivateAndReset()
+	cached := func(next Invoker) Invoker {
+		return func(inv *Invocation) error {
+			return json.Unmarshal([]byte(`[{"uuid":"cachedId"}]`), inv.Result)
+		}
+	}
+	ecxClient := NewClient(context.Background(), baseURL, testHc, WithInterceptors(cached))
+
+	//When
+	ports, err := ecxClient.GetUserPorts()
+
+	//Then
+	assert.Nil(t, err, "Client should not return an error")
+	assert.Equal(t, 0, httpmock.GetTotalCallCount(), "No request is sent")
+	assert.Equal(t, 1, len(ports), "Ports are returned")
+	assert.Equal(t, "cachedId", StringValue(ports[0].UUID), "Port UUID matches")
+}
+
+func TestInterceptors_pages(t *testing.T) {
+	//Given
+	pages := &connectionPages{total: 7, page: pagedTestConnections(7, 3)}
+	testHc := &http.Client{}
+	registerConnectionPages(testHc, pages)
+	defer httpmock.DeactivateAndReset()
+	var queries []string
+	record := func(next Invoker) Invoker {
+		return func(inv *Invocation) error {
+			queries = append(queries, inv.Query.Get("pageNumber"))
+			return next(inv)
+		}
+	}
+	ecxClient := NewClient(context.Background(), baseURL, testHc, WithInterceptors(record)).SetPageConcurrency(1)
+	ecxClient.SetPageSize(3)
+
+	//When
+	conns, err := ecxClient.GetL2OutgoingConnections(nil)
+
+	//Then
+	assert.Nil(t, err, "Client should not return an error")
+	assert.Equal(t, 7, len(conns), "Number of connections matches")
+	assert.Equal(t, []string{"", "1", "2"}, queries, "Each page request is intercepted")
+}
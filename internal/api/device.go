@@ -0,0 +1,35 @@
+package api
+
+//Device describes Network Edge virtual device
+type Device struct {
+	UUID           *string           `json:"uuid,omitempty"`
+	Name           *string           `json:"name,omitempty"`
+	DeviceTypeCode *string           `json:"deviceTypeCode,omitempty"`
+	Status         *string           `json:"status,omitempty"`
+	MetroCode      *string           `json:"metroCode,omitempty"`
+	IBX            *string           `json:"ibx,omitempty"`
+	Region         *string           `json:"region,omitempty"`
+	RedundancyType *string           `json:"redundancyType,omitempty"`
+	RedundantUUID  *string           `json:"redundantUuid,omitempty"`
+	Interfaces     []DeviceInterface `json:"interfaces,omitempty"`
+}
+
+//DeviceInterface describes Network Edge virtual device interface
+type DeviceInterface struct {
+	ID                *int    `json:"id,omitempty"`
+	Name              *string `json:"name,omitempty"`
+	Status            *string `json:"status,omitempty"`
+	OperationalStatus *string `json:"operationalStatus,omitempty"`
+	MACAddress        *string `json:"macAddress,omitempty"`
+	IPAddress         *string `json:"ipAddress,omitempty"`
+	AssignedType      *string `json:"assignedType,omitempty"`
+	Type              *string `json:"type,omitempty"`
+}
+
+//DevicesResponse describes collection of Network Edge virtual devices
+type DevicesResponse struct {
+	TotalCount *int     `json:"totalCount,omitempty"`
+	PageNumber *int     `json:"pageNumber,omitempty"`
+	PageSize   *int     `json:"pageSize,omitempty"`
+	Data       []Device `json:"data,omitempty"`
+}
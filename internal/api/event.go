@@ -0,0 +1,45 @@
+package api
+
+//Event describes Equinix Fabric event notification payload
+type Event struct {
+	ID   *string    `json:"id,omitempty"`
+	Type *string    `json:"type,omitempty"`
+	Time *string    `json:"time,omitempty"`
+	Data *EventData `json:"data,omitempty"`
+}
+
+//EventData describes connection related data of an event notification
+type EventData struct {
+	ConnectionUUID         *string `json:"connectionUUID,omitempty"`
+	ConnectionName         *string `json:"connectionName,omitempty"`
+	Status                 *string `json:"status,omitempty"`
+	PreviousStatus         *string `json:"previousStatus,omitempty"`
+	ProviderStatus         *string `json:"providerStatus,omitempty"`
+	PreviousProviderStatus *string `json:"previousProviderStatus,omitempty"`
+	Message                *string `json:"message,omitempty"`
+}
+
+//EventSubscription describes event notification subscription
+type EventSubscription struct {
+	UUID       *string  `json:"uuid,omitempty"`
+	Name       *string  `json:"name,omitempty"`
+	URL        *string  `json:"url,omitempty"`
+	EventTypes []string `json:"eventTypes,omitempty"`
+	Secret     *string  `json:"secret,omitempty"`
+	State      *string  `json:"state,omitempty"`
+}
+
+//CreateEventSubscriptionResponse post event subscription response
+type CreateEventSubscriptionResponse struct {
+	UUID *string `json:"uuid,omitempty"`
+}
+
+//EventSubscriptionsResponse describes collection of event subscriptions
+type EventSubscriptionsResponse struct {
+	IsFirstPage *bool               `json:"isFirstPage,omitempty"`
+	IsLastPage  *bool               `json:"isLastPage,omitempty"`
+	TotalCount  *int                `json:"totalCount,omitempty"`
+	PageSize    *int                `json:"pageSize,omitempty"`
+	Content     []EventSubscription `json:"content,omitempty"`
+	PageNumber  *int                `json:"pageNumber,omitempty"`
+}
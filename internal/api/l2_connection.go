@@ -28,6 +28,8 @@ type L2ConnectionResponse struct {
 	RedundantUUID       *string                      `json:"redundantUUID,omitempty"`
 	ActionDetails       []L2ConnectionActionDetail   `json:"actionDetails,omitempty"`
 	VendorToken         *string                      `json:"vendorToken,omitempty"`
+	ZSideDeviceUUID     *string                      `json:"zSideVirtualDeviceUUID,omitempty"`
+	ZSideInterfaceID    *int                         `json:"zSideInterfaceId,omitempty"`
 }
 
 //DeleteL2ConnectionResponse l2 connection delete response
@@ -56,6 +58,8 @@ type L2ConnectionRequest struct {
 	PrimaryZSideServiceToken   *string                      `json:"primaryZSideServiceToken,omitempty"`
 	PrimaryZSideVlanSTag       *int                         `json:"primaryZSideVlanSTag,omitempty"`
 	PrimaryZSideVlanCTag       *int                         `json:"primaryZSideVlanCTag,omitempty"`
+	PrimaryZSideDeviceUUID     *string                      `json:"primaryZSideVirtualDeviceUUID,omitempty"`
+	PrimaryZSideInterfaceID    *int                         `json:"primaryZSideInterfaceId,omitempty"`
 	SecondaryName              *string                      `json:"secondaryName,omitempty"`
 	SecondaryPortUUID          *string                      `json:"secondaryPortUUID,omitempty"`
 	SecondaryVirtualDeviceUUID *string                      `json:"secondaryVirtualDeviceUUID,omitempty"`
@@ -34,6 +34,7 @@ type L2ServiceProfile struct {
 	ProfileEncapsulation                *string                         `json:"profileEncapsulation,omitempty"`
 	GlobalOrganization                  *string                         `json:"globalOrganization,omitempty"`
 	OrganizationName                    *string                         `json:"organizationName,omitempty"`
+	LastUpdatedDate                     *string                         `json:"lastUpdatedDate,omitempty"`
 }
 
 //L2ServiceProfileDeleteResponse delete l2 service profile response
@@ -74,6 +75,16 @@ type L2SellerProfilesResponse struct {
 	Content     []L2ServiceProfile `json:"content,omitempty"`
 }
 
+//L2ServiceProfilesResponse response with list of l2 service profiles owned by customer account
+type L2ServiceProfilesResponse struct {
+	IsLastPage  *bool              `json:"isLastPage"`
+	IsFirstPage *bool              `json:"isFirstPage"`
+	TotalCount  *int               `json:"totalCount,omitempty"`
+	PageSize    *int               `json:"pageSize,omitempty"`
+	PageNumber  *int               `json:"pageNumber,omitempty"`
+	Content     []L2ServiceProfile `json:"content,omitempty"`
+}
+
 //L2SellerProfileMetro describces details of a metro in which service provider is present
 type L2SellerProfileMetro struct {
 	Code    *string           `json:"code,omitempty"`
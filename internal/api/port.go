@@ -2,14 +2,32 @@ package api
 
 //Port describes Equinix Fabric's user port
 type Port struct {
-	UUID            *string `json:"uuid,omitempty"`
-	Name            *string `json:"name,omitempty"`
-	Region          *string `json:"region,omitempty"`
-	IBX             *string `json:"ibx,omitempty"`
-	MetroCode       *string `json:"metroCode,omitempty"`
-	DevicePriority  *string `json:"devicePriority,omitempty"`
-	Encapsulation   *string `json:"encapsulation,omitempty"`
-	Buyout          *bool   `json:"buyout"`
-	TotalBandwidth  *int64  `json:"totalBandwidth,omitempty"`
-	ProvisionStatus *string `json:"provisionStatus,omitempty"`
+	UUID              *string        `json:"uuid,omitempty"`
+	Name              *string        `json:"name,omitempty"`
+	Region            *string        `json:"region,omitempty"`
+	IBX               *string        `json:"ibx,omitempty"`
+	MetroCode         *string        `json:"metroCode,omitempty"`
+	Device            *string        `json:"device,omitempty"`
+	DeviceGroup       *string        `json:"deviceGroup,omitempty"`
+	DevicePriority    *string        `json:"devicePriority,omitempty"`
+	Encapsulation     *string        `json:"encapsulation,omitempty"`
+	Buyout            *bool          `json:"buyout"`
+	TotalBandwidth    *int64         `json:"totalBandwidth,omitempty"`
+	ProvisionStatus   *string        `json:"provisionStatus,omitempty"`
+	Lag               *bool          `json:"lag,omitempty"`
+	Layer3Enabled     *bool          `json:"layer3Enabled,omitempty"`
+	SharedPortType    *string        `json:"sharedPortType,omitempty"`
+	SharedPortProduct *string        `json:"sharedPortProduct,omitempty"`
+	UserPorts         []PhysicalPort `json:"userPorts,omitempty"`
+}
+
+//PhysicalPort describes physical port that is a member of Equinix Fabric's user port
+type PhysicalPort struct {
+	Bandwidth           *int64  `json:"bandwidth,omitempty"`
+	CrossConnectID      *string `json:"crossConnectId,omitempty"`
+	CabinetNumber       *string `json:"cabinetNumber,omitempty"`
+	CageNumber          *string `json:"cageNumber,omitempty"`
+	PatchPanelName      *string `json:"patchPanelName,omitempty"`
+	TagProtocolID       *string `json:"tagProtocolId,omitempty"`
+	PortProvisionStatus *string `json:"portProvisionStatus,omitempty"`
 }
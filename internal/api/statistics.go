@@ -0,0 +1,20 @@
+package api
+
+//TrafficStatisticsResponse describes traffic statistics of a port or a connection
+//collected over a given time range
+type TrafficStatisticsResponse struct {
+	StartDateTime  *string         `json:"startDateTime,omitempty"`
+	EndDateTime    *string         `json:"endDateTime,omitempty"`
+	MetricInterval *string         `json:"metricInterval,omitempty"`
+	Unit           *string         `json:"unit,omitempty"`
+	Metrics        []TrafficMetric `json:"metrics,omitempty"`
+}
+
+//TrafficMetric describes traffic statistics sample for a single metric interval
+type TrafficMetric struct {
+	IntervalEndDateTime    *string  `json:"intervalEndDateTime,omitempty"`
+	InboundBandwidth       *float64 `json:"inboundBandwidth,omitempty"`
+	OutboundBandwidth      *float64 `json:"outboundBandwidth,omitempty"`
+	InboundPacketsDropped  *int64   `json:"inboundPacketsDropped,omitempty"`
+	OutboundPacketsDropped *int64   `json:"outboundPacketsDropped,omitempty"`
+}
@@ -0,0 +1,28 @@
+package ecx
+
+import "time"
+
+//Metrics receives measurements of Equinix Fabric API requests made by RestClient.
+//Implementations have to be safe for concurrent use, as pages of paginated collections
+//are fetched concurrently
+type Metrics interface {
+	//ObserveRequest is called once HTTP request of a given client operation, i.e. GetL2Connection,
+	//has finished. Status code is zero when no HTTP response was received
+	ObserveRequest(operation string, statusCode int, duration time.Duration, err error)
+	//ObservePages is called once all pages of a paginated collection retrieved by a given
+	//client operation were fetched, with number of pages requested
+	ObservePages(operation string, pages int)
+}
+
+//SetMetrics sets metrics receiver that observes API requests made by the client.
+//Client without metrics, which is the default, does not take any measurements
+func (c *RestClient) SetMetrics(metrics Metrics) *RestClient {
+	c.metrics = metrics
+	return c
+}
+
+func (c RestClient) observePages(operation string, pages int) {
+	if c.metrics != nil {
+		c.metrics.ObservePages(operation, pages)
+	}
+}
@@ -0,0 +1,93 @@
+package ecx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+type observedRequest struct {
+	operation  string
+	statusCode int
+	err        error
+}
+
+type recordingMetrics struct {
+	mu       sync.Mutex
+	requests []observedRequest
+	pages    map[string]int
+}
+
+func (m *recordingMetrics) ObserveRequest(operation string, statusCode int, duration time.Duration, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requests = append(m.requests, observedRequest{operation: operation, statusCode: statusCode, err: err})
+}
+
+func (m *recordingMetrics) ObservePages(operation string, pages int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.pages == nil {
+		m.pages = make(map[string]int)
+	}
+	m.pages[operation] += pages
+}
+
+func TestMetrics_request(t *testing.T) {
+	//Given
+	testHc := &http.Client{}
+	httpmock.ActivateNonDefault(testHc)
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ecx/v3/l2/connections/existing", baseURL),
+		httpmock.NewStringResponder(200, "{}"))
+	httpmock.RegisterResponder("DELETE", fmt.Sprintf("%s/ecx/v3/l2/connections/missing", baseURL),
+		httpmock.NewStringResponder(404, ""))
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ecx/v3/port/userport", baseURL),
+		httpmock.NewErrorResponder(errors.New("connection reset")))
+	defer httpmock.DeactivateAndReset()
+	metrics := &recordingMetrics{}
+	ecxClient := NewClient(context.Background(), baseURL, testHc).SetMetrics(metrics)
+
+	//When
+	_, getErr := ecxClient.GetL2Connection("existing")
+	deleteErr := ecxClient.DeleteL2Connection("missing")
+	_, portsErr := ecxClient.GetUserPorts()
+
+	//Then
+	assert.Nil(t, getErr, "Get should not return an error")
+	assert.NotNil(t, deleteErr, "Delete should return an error")
+	assert.NotNil(t, portsErr, "Get ports should return an error")
+	assert.Equal(t, []observedRequest{
+		{operation: "GetL2Connection", statusCode: 200},
+		{operation: "DeleteL2Connection", statusCode: 404, err: deleteErr},
+		{operation: "GetUserPorts", statusCode: 0, err: portsErr},
+	}, metrics.requests, "Observed requests match")
+}
+
+func TestMetrics_pages(t *testing.T) {
+	//Given
+	pages := &connectionPages{total: 7, page: pagedTestConnections(7, 3)}
+	testHc := &http.Client{}
+	registerConnectionPages(testHc, pages)
+	defer httpmock.DeactivateAndReset()
+	metrics := &recordingMetrics{}
+	ecxClient := NewClient(context.Background(), baseURL, testHc).SetMetrics(metrics)
+	ecxClient.SetPageSize(3)
+
+	//When
+	_, err := ecxClient.GetL2OutgoingConnections(nil)
+
+	//Then
+	assert.Nil(t, err, "Client should not return an error")
+	assert.Equal(t, map[string]int{"GetL2OutgoingConnections": 3}, metrics.pages, "Observed pages match")
+	assert.Equal(t, 3, len(metrics.requests), "Each page request is observed")
+	for _, req := range metrics.requests {
+		assert.Equal(t, observedRequest{operation: "GetL2OutgoingConnections", statusCode: 200}, req, "Observed page request matches")
+	}
+}
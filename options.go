@@ -0,0 +1,202 @@
+package ecx
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/equinix/oauth2-go"
+	xoauth2 "golang.org/x/oauth2"
+)
+
+const userAgent = "equinix/ecx-go"
+
+//ClientOption configures RestClient created with NewClient or NewClientWithOptions
+type ClientOption func(o *clientOptions)
+
+type clientOptions struct {
+	interceptors    []Interceptor
+	userAgentSuffix string
+	headers         map[string]string
+	pageSize        int
+	timeout         time.Duration
+	proxyURL        *url.URL
+	tlsConfig       *tls.Config
+	clientID        string
+	clientSecret    string
+	transport       *http.Transport
+}
+
+//WithInterceptors adds interceptors that wrap every API request made by the client.
+//First interceptor is the outermost one, i.e. it is called first and returns last
+func WithInterceptors(interceptors ...Interceptor) ClientOption {
+	return func(o *clientOptions) {
+		o.interceptors = append(o.interceptors, interceptors...)
+	}
+}
+
+//WithUserAgentSuffix appends a given suffix, i.e. application name and version,
+//to client's User-agent header
+func WithUserAgentSuffix(suffix string) ClientOption {
+	return func(o *clientOptions) {
+		o.userAgentSuffix = suffix
+	}
+}
+
+//WithHeaders sets headers added to every API request
+func WithHeaders(headers map[string]string) ClientOption {
+	return func(o *clientOptions) {
+		if o.headers == nil {
+			o.headers = make(map[string]string, len(headers))
+		}
+		for k, v := range headers {
+			o.headers[k] = v
+		}
+	}
+}
+
+//WithPageSize sets default page size used when retrieving paginated collections
+func WithPageSize(pageSize int) ClientOption {
+	return func(o *clientOptions) {
+		o.pageSize = pageSize
+	}
+}
+
+//WithTimeout sets time limit for API requests, including token requests.
+//Option applies to HTTP client created by NewClientWithOptions
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(o *clientOptions) {
+		o.timeout = timeout
+	}
+}
+
+//WithProxy sets proxy server URL for API requests. Without the option, proxy is determined
+//by HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables.
+//Option applies to HTTP client created by NewClientWithOptions
+func WithProxy(proxyURL *url.URL) ClientOption {
+	return func(o *clientOptions) {
+		o.proxyURL = proxyURL
+	}
+}
+
+//WithTLSConfig sets TLS configuration for API requests, i.e. with custom root CAs.
+//Option applies to HTTP client created by NewClientWithOptions
+func WithTLSConfig(tlsConfig *tls.Config) ClientOption {
+	return func(o *clientOptions) {
+		o.tlsConfig = tlsConfig
+	}
+}
+
+//WithCredentials sets client ID and secret of Equinix application used to obtain
+//oAuth2 tokens for API requests. Option applies to HTTP client created by NewClientWithOptions
+func WithCredentials(clientID, clientSecret string) ClientOption {
+	return func(o *clientOptions) {
+		o.clientID = clientID
+		o.clientSecret = clientSecret
+	}
+}
+
+//NewClientWithOptions creates new Equinix Fabric REST API client with a given baseURL and
+//options. HTTP client is created with given timeout, proxy and TLS configuration and, when
+//credentials are given, obtains oAuth2 tokens from baseURL's token endpoint.
+//The returned client is not valid beyond the lifetime of the context
+func NewClientWithOptions(ctx context.Context, baseURL string, options ...ClientOption) (*RestClient, error) {
+	if baseURL == "" {
+		return nil, errors.New("baseURL is required")
+	}
+	o := newClientOptions(options)
+	if (o.clientID == "") != (o.clientSecret == "") {
+		return nil, errors.New("both client ID and client secret are required")
+	}
+	transport := o.transport
+	if transport == nil || o.proxyURL != nil || o.tlsConfig != nil {
+		transport = defaultTransport()
+		if o.transport != nil {
+			transport = o.transport.Clone()
+		}
+		if o.proxyURL != nil {
+			transport.Proxy = http.ProxyURL(o.proxyURL)
+		}
+		if o.tlsConfig != nil {
+			transport.TLSClientConfig = o.tlsConfig
+		}
+	}
+	httpClient := &http.Client{Transport: transport, Timeout: o.timeout}
+	if o.clientID != "" {
+		authConfig := oauth2.Config{
+			ClientID:     o.clientID,
+			ClientSecret: o.clientSecret,
+			BaseURL:      baseURL,
+		}
+		//token source uses configured client only for token requests, API requests
+		//have to be authorized by a transport that wraps configured one
+		httpClient = &http.Client{
+			Transport: &xoauth2.Transport{
+				Source: authConfig.TokenSource(ctx, httpClient),
+				Base:   transport,
+			},
+			Timeout: o.timeout,
+		}
+	}
+	return newClient(ctx, baseURL, httpClient, o), nil
+}
+
+//defaultTransport returns copy of http.DefaultTransport or, when it was replaced
+//with other implementation, new transport that uses proxy from environment
+func defaultTransport() *http.Transport {
+	if transport, ok := http.DefaultTransport.(*http.Transport); ok {
+		return transport.Clone()
+	}
+	return &http.Transport{Proxy: http.ProxyFromEnvironment}
+}
+
+//withTransport sets transport shared by HTTP clients created by NewClientWithOptions.
+//Clients with own proxy or TLS configuration use a copy of the transport
+func withTransport(transport *http.Transport) ClientOption {
+	return func(o *clientOptions) {
+		o.transport = transport
+	}
+}
+
+func newClientOptions(options []ClientOption) clientOptions {
+	o := clientOptions{}
+	for _, option := range options {
+		option(&o)
+	}
+	return o
+}
+
+//httpClientOptions returns names of set options that configure HTTP client
+func (o clientOptions) httpClientOptions() []string {
+	var names []string
+	if o.timeout > 0 {
+		names = append(names, "WithTimeout")
+	}
+	if o.proxyURL != nil {
+		names = append(names, "WithProxy")
+	}
+	if o.tlsConfig != nil {
+		names = append(names, "WithTLSConfig")
+	}
+	if o.clientID != "" || o.clientSecret != "" {
+		names = append(names, "WithCredentials")
+	}
+	return names
+}
+
+//applyOptions applies options that configure RestClient on top of given HTTP client
+func (c *RestClient) applyOptions(o clientOptions) {
+	c.interceptors = o.interceptors
+	if o.userAgentSuffix != "" {
+		c.SetHeader("User-agent", userAgent+" "+o.userAgentSuffix)
+	}
+	for k, v := range o.headers {
+		c.SetHeader(k, v)
+	}
+	if o.pageSize > 0 {
+		c.SetPageSize(o.pageSize)
+	}
+}
@@ -0,0 +1,107 @@
+package ecx
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/equinix/ecx-go/v2/internal/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewClientWithOptions(t *testing.T) {
+	//Given
+	var tokenRequest map[string]string
+	var portsRequest *http.Request
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/oauth2/v1/token":
+			json.NewDecoder(r.Body).Decode(&tokenRequest)
+			w.Write([]byte(`{"access_token":"token","token_timeout":"3600"}`))
+		case "/ecx/v3/port/userport":
+			portsRequest = r
+			json.NewEncoder(w).Encode([]api.Port{{UUID: String("portId")}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(server.Certificate())
+
+	//When
+	ecxClient, err := NewClientWithOptions(context.Background(), server.URL,
+		WithCredentials("clientId", "secret"),
+		WithTLSConfig(&tls.Config{RootCAs: rootCAs}),
+		WithTimeout(10*time.Second),
+		WithUserAgentSuffix("controller/1.0"),
+		WithHeaders(map[string]string{"X-Correlation-Source": "controller"}),
+		WithPageSize(25),
+	)
+	if err != nil {
+		assert.Failf(t, "Cannot create client due to %s", err.Error())
+	}
+	ports, err := ecxClient.GetUserPorts()
+
+	//Then
+	assert.Nil(t, err, "Client should not return an error")
+	assert.Equal(t, 1, len(ports), "Ports are returned")
+	assert.Equal(t, "clientId", tokenRequest["client_id"], "Token is requested with client ID")
+	assert.Equal(t, "secret", tokenRequest["client_secret"], "Token is requested with client secret")
+	assert.Equal(t, "Bearer token", portsRequest.Header.Get("Authorization"), "Request is authorized")
+	assert.Equal(t, "equinix/ecx-go controller/1.0", portsRequest.Header.Get("User-agent"), "User agent matches")
+	assert.Equal(t, "controller", portsRequest.Header.Get("X-Correlation-Source"), "Custom header matches")
+	assert.Equal(t, 25, ecxClient.PageSize, "Page size matches")
+}
+
+func TestNewClientWithOptions_untrustedServer(t *testing.T) {
+	//Given
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	ecxClient, err := NewClientWithOptions(context.Background(), server.URL, WithCredentials("clientId", "secret"))
+	if err != nil {
+		assert.Failf(t, "Cannot create client due to %s", err.Error())
+	}
+
+	//When
+	_, err = ecxClient.GetUserPorts()
+
+	//Then
+	assert.NotNil(t, err, "Client should return an error")
+}
+
+func TestNewClientWithOptions_incompleteCredentials(t *testing.T) {
+	//When
+	ecxClient, err := NewClientWithOptions(context.Background(), baseURL, WithCredentials("clientId", ""))
+
+	//Then
+	assert.NotNil(t, err, "NewClientWithOptions should return an error")
+	assert.Nil(t, ecxClient, "NewClientWithOptions should not return a client")
+}
+
+func TestNewClient_httpClientOptions(t *testing.T) {
+	//Given
+	var logged bytes.Buffer
+	log.SetOutput(&logged)
+	defer log.SetOutput(os.Stderr)
+
+	//When
+	NewClient(context.Background(), baseURL, &http.Client{}, WithTimeout(time.Second), WithPageSize(10))
+	timeoutLog := logged.String()
+	logged.Reset()
+	NewClient(context.Background(), baseURL, &http.Client{}, WithPageSize(10))
+
+	//Then
+	assert.Contains(t, timeoutLog, "WithTimeout", "Not applied option is logged")
+	assert.NotContains(t, timeoutLog, "WithPageSize", "Applied option is not logged")
+	assert.Empty(t, logged.String(), "Nothing is logged when all options are applied")
+}
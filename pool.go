@@ -0,0 +1,280 @@
+package ecx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+//ClientPool holds Equinix Fabric clients of many customer accounts and sub-accounts,
+//keyed by account name, i.e. configuration profile name. Clients created by the pool share
+//HTTP transport and, when configured, rate limiter. Sub-account clients share HTTP client,
+//including oAuth2 token, of their parent account and identify sub-account with account header.
+//Pool is safe for concurrent use
+type ClientPool struct {
+	ctx           context.Context
+	transport     *http.Transport
+	limiter       *rate.Limiter
+	accountHeader string
+	options       []ClientOption
+	mu            sync.RWMutex
+	accounts      map[string]poolAccount
+	names         []string
+}
+
+type poolAccount struct {
+	client     *RestClient
+	baseURL    string
+	httpClient *http.Client
+}
+
+//PoolOption configures ClientPool created with NewClientPool
+type PoolOption func(p *ClientPool)
+
+//PoolError describes failures of an operation run across pool accounts
+type PoolError struct {
+	//Errors maps names of accounts to errors returned for them
+	Errors map[string]error
+}
+
+func (e PoolError) Error() string {
+	names := make([]string, 0, len(e.Errors))
+	for name := range e.Errors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	errStrs := make([]string, len(names))
+	for i := range names {
+		errStrs[i] = fmt.Sprintf("%s: %s", names[i], e.Errors[names[i]])
+	}
+	return "operation failed for accounts: " + strings.Join(errStrs, "; ")
+}
+
+//AccountL2Connection is a layer 2 connection of a given pool account
+type AccountL2Connection struct {
+	Account string
+	L2Connection
+}
+
+//WithPoolRateLimit sets rate limiter shared by API requests of all pool clients
+func WithPoolRateLimit(limiter *rate.Limiter) PoolOption {
+	return func(p *ClientPool) {
+		p.limiter = limiter
+	}
+}
+
+//WithPoolAccountHeader sets name of HTTP header that identifies sub-account
+//in requests made by sub-account clients
+func WithPoolAccountHeader(header string) PoolOption {
+	return func(p *ClientPool) {
+		p.accountHeader = header
+	}
+}
+
+//WithPoolTransport sets HTTP transport shared by pool clients, i.e. with proxy or TLS
+//configuration. Copy of http.DefaultTransport is used by default
+func WithPoolTransport(transport *http.Transport) PoolOption {
+	return func(p *ClientPool) {
+		p.transport = transport
+	}
+}
+
+//WithPoolClientOptions sets options applied to every pool client before account's options
+func WithPoolClientOptions(options ...ClientOption) PoolOption {
+	return func(p *ClientPool) {
+		p.options = append(p.options, options...)
+	}
+}
+
+//NewClientPool creates new, empty client pool with a given context and options.
+//Pool clients are not valid beyond the lifetime of the context
+func NewClientPool(ctx context.Context, options ...PoolOption) *ClientPool {
+	p := &ClientPool{
+		ctx:      ctx,
+		accounts: make(map[string]poolAccount),
+	}
+	for _, option := range options {
+		option(p)
+	}
+	if p.transport == nil {
+		p.transport = defaultTransport()
+	}
+	return p
+}
+
+//AddAccount creates client of an account with a given name according to a given configuration,
+//i.e. one loaded with LoadConfig, and options
+func (p *ClientPool) AddAccount(name string, conf Config, options ...ClientOption) error {
+	confOptions, err := conf.ClientOptions()
+	if err != nil {
+		return err
+	}
+	clientOptions := append(p.clientOptions(), withTransport(p.transport))
+	clientOptions = append(clientOptions, confOptions...)
+	clientOptions = append(clientOptions, options...)
+	clientOptions = append(clientOptions, p.limiterOptions()...)
+	client, err := NewClientWithOptions(p.ctx, conf.BaseURL, clientOptions...)
+	if err != nil {
+		return fmt.Errorf("cannot create client of account %q: %w", name, err)
+	}
+	return p.add(name, poolAccount{client: client, baseURL: conf.BaseURL, httpClient: client.GetClient()})
+}
+
+//AddSubAccount creates client of a sub-account with a given name and account number.
+//Sub-account client uses HTTP client, including credentials, of a given parent account
+//and sends account number in pool's account header. Options that configure HTTP client
+//cannot be given for a sub-account
+func (p *ClientPool) AddSubAccount(name string, parent string, accountNumber string, options ...ClientOption) error {
+	if p.accountHeader == "" {
+		return errors.New("pool has no account header to identify sub-accounts")
+	}
+	if accountNumber == "" {
+		return errors.New("account number is required")
+	}
+	if ignored := newClientOptions(options).httpClientOptions(); len(ignored) > 0 {
+		return fmt.Errorf("sub-account uses parent's HTTP client, %s options cannot be applied", strings.Join(ignored, ", "))
+	}
+	p.mu.RLock()
+	parentAccount, ok := p.accounts[parent]
+	p.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("parent account %q not found in pool", parent)
+	}
+	clientOptions := append(p.clientOptions(), options...)
+	clientOptions = append(clientOptions, WithHeaders(map[string]string{p.accountHeader: accountNumber}))
+	clientOptions = append(clientOptions, p.limiterOptions()...)
+	client := newClient(p.ctx, parentAccount.baseURL, parentAccount.httpClient, newClientOptions(clientOptions))
+	return p.add(name, poolAccount{client: client, baseURL: parentAccount.baseURL, httpClient: parentAccount.httpClient})
+}
+
+//RemoveAccount removes client of an account with a given name. Clients of sub-accounts
+//of removed account remain in the pool
+func (p *ClientPool) RemoveAccount(name string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, ok := p.accounts[name]; !ok {
+		return
+	}
+	delete(p.accounts, name)
+	for i := range p.names {
+		if p.names[i] == name {
+			p.names = append(p.names[:i], p.names[i+1:]...)
+			break
+		}
+	}
+}
+
+//Client returns client of an account with a given name
+func (p *ClientPool) Client(name string) (*RestClient, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	account, ok := p.accounts[name]
+	if !ok {
+		return nil, fmt.Errorf("account %q not found in pool", name)
+	}
+	return account.client, nil
+}
+
+//Accounts returns names of pool accounts in order they were added
+func (p *ClientPool) Accounts() []string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	names := make([]string, len(p.names))
+	copy(names, p.names)
+	return names
+}
+
+//ForEachAccount calls a given function concurrently with client of each pool account.
+//PoolError is returned when function fails for any of the accounts
+func (p *ClientPool) ForEachAccount(fn func(account string, client *RestClient) error) error {
+	names := p.Accounts()
+	return p.forEachAccount(names, func(i int, client *RestClient) error {
+		return fn(names[i], client)
+	})
+}
+
+//GetL2OutgoingConnections retrieves outgoing layer 2 connections of all pool accounts,
+//optionally filtered by given statuses. Connections are merged in order of accounts.
+//When retrieval fails for some accounts, connections of remaining accounts are returned
+//together with PoolError
+func (p *ClientPool) GetL2OutgoingConnections(statuses []string) ([]AccountL2Connection, error) {
+	names := p.Accounts()
+	perAccount := make([][]L2Connection, len(names))
+	err := p.forEachAccount(names, func(i int, client *RestClient) error {
+		conns, err := client.GetL2OutgoingConnections(statuses)
+		perAccount[i] = conns
+		return err
+	})
+	merged := make([]AccountL2Connection, 0)
+	for i := range perAccount {
+		for _, conn := range perAccount[i] {
+			merged = append(merged, AccountL2Connection{Account: names[i], L2Connection: conn})
+		}
+	}
+	return merged, err
+}
+
+//forEachAccount calls a given function concurrently with index and client of each
+//of given accounts
+func (p *ClientPool) forEachAccount(names []string, fn func(i int, client *RestClient) error) error {
+	errs := make([]error, len(names))
+	wg := sync.WaitGroup{}
+	for i := range names {
+		client, err := p.Client(names[i])
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+		wg.Add(1)
+		go func(i int, client *RestClient) {
+			defer wg.Done()
+			errs[i] = fn(i, client)
+		}(i, client)
+	}
+	wg.Wait()
+	poolErr := PoolError{Errors: make(map[string]error)}
+	for i := range names {
+		if errs[i] != nil {
+			poolErr.Errors[names[i]] = errs[i]
+		}
+	}
+	if len(poolErr.Errors) > 0 {
+		return poolErr
+	}
+	return nil
+}
+
+func (p *ClientPool) add(name string, account poolAccount) error {
+	if name == "" {
+		return errors.New("account name is required")
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, ok := p.accounts[name]; ok {
+		return fmt.Errorf("account %q already exists in pool", name)
+	}
+	p.accounts[name] = account
+	p.names = append(p.names, name)
+	return nil
+}
+
+func (p *ClientPool) clientOptions() []ClientOption {
+	options := make([]ClientOption, len(p.options))
+	copy(options, p.options)
+	return options
+}
+
+//limiterOptions returns options that add pool's rate limiter as the innermost interceptor,
+//so that every request sent, including retried ones, is limited
+func (p *ClientPool) limiterOptions() []ClientOption {
+	if p.limiter == nil {
+		return nil
+	}
+	return []ClientOption{WithInterceptors(RateLimitInterceptor(p.limiter))}
+}
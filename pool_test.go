@@ -0,0 +1,191 @@
+package ecx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/equinix/ecx-go/v2/internal/api"
+	"github.com/stretchr/testify/assert"
+	xoauth2 "golang.org/x/oauth2"
+	"golang.org/x/time/rate"
+)
+
+const testAccountHeader = "X-Test-Account"
+
+//newTestPoolServer serves tokens issued to client IDs and outgoing connections
+//of an account identified by token and account header. Account "failing" gets an error
+func newTestPoolServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/oauth2/v1/token":
+			tokenRequest := make(map[string]string)
+			json.NewDecoder(r.Body).Decode(&tokenRequest)
+			json.NewEncoder(w).Encode(map[string]string{"access_token": tokenRequest["client_id"], "token_timeout": "3600"})
+		case "/ecx/v3/l2/buyer/connections":
+			account := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if sub := r.Header.Get(testAccountHeader); sub != "" {
+				account += "/" + sub
+			}
+			if account == "failing" {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			json.NewEncoder(w).Encode(api.L2BuyerConnectionsResponse{
+				TotalCount: Int(1),
+				Content:    []api.L2ConnectionResponse{{UUID: String(account + "-conn")}},
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func testPoolConfig(server *httptest.Server, clientID string) Config {
+	return Config{BaseURL: server.URL, ClientID: clientID, ClientSecret: "secret"}
+}
+
+func TestClientPool_GetL2OutgoingConnections(t *testing.T) {
+	//Given
+	server := newTestPoolServer()
+	defer server.Close()
+	pool := NewClientPool(context.Background(), WithPoolAccountHeader(testAccountHeader))
+	assert.Nil(t, pool.AddAccount("first", testPoolConfig(server, "first")), "First account is added")
+	assert.Nil(t, pool.AddAccount("second", testPoolConfig(server, "second")), "Second account is added")
+	assert.Nil(t, pool.AddSubAccount("first-sub", "first", "123"), "Sub-account is added")
+
+	//When
+	conns, err := pool.GetL2OutgoingConnections(nil)
+
+	//Then
+	assert.Nil(t, err, "Pool should not return an error")
+	assert.Equal(t, []string{"first", "second", "first-sub"}, pool.Accounts(), "Accounts match")
+	assert.Equal(t, 3, len(conns), "Number of connections matches")
+	expected := map[string]string{"first": "first-conn", "second": "second-conn", "first-sub": "first/123-conn"}
+	for i, name := range pool.Accounts() {
+		assert.Equal(t, name, conns[i].Account, "Connections are attributed to accounts in order")
+		assert.Equal(t, expected[name], StringValue(conns[i].UUID), "Connection of account matches")
+	}
+}
+
+func TestClientPool_sharedTransport(t *testing.T) {
+	//Given
+	server := newTestPoolServer()
+	defer server.Close()
+	pool := NewClientPool(context.Background(), WithPoolAccountHeader(testAccountHeader))
+	pool.AddAccount("first", testPoolConfig(server, "first"))
+	pool.AddAccount("second", testPoolConfig(server, "second"))
+	pool.AddSubAccount("first-sub", "first", "123")
+
+	//When
+	first, _ := pool.Client("first")
+	second, _ := pool.Client("second")
+	sub, _ := pool.Client("first-sub")
+
+	//Then
+	assert.Equal(t, pool.transport, first.GetClient().Transport.(*xoauth2.Transport).Base, "First account uses pool transport")
+	assert.Equal(t, pool.transport, second.GetClient().Transport.(*xoauth2.Transport).Base, "Second account uses pool transport")
+	assert.True(t, first.GetClient() == sub.GetClient(), "Sub-account uses parent's HTTP client")
+}
+
+func TestClientPool_partialFailure(t *testing.T) {
+	//Given
+	server := newTestPoolServer()
+	defer server.Close()
+	pool := NewClientPool(context.Background())
+	pool.AddAccount("ok", testPoolConfig(server, "ok"))
+	pool.AddAccount("failing", testPoolConfig(server, "failing"))
+
+	//When
+	conns, err := pool.GetL2OutgoingConnections(nil)
+
+	//Then
+	poolErr := PoolError{}
+	assert.True(t, errors.As(err, &poolErr), "Error is a PoolError")
+	assert.Equal(t, 1, len(poolErr.Errors), "Number of failed accounts matches")
+	assert.NotNil(t, poolErr.Errors["failing"], "Failed account matches")
+	assert.Equal(t, []AccountL2Connection{{Account: "ok", L2Connection: L2Connection{UUID: String("ok-conn")}}},
+		[]AccountL2Connection{{Account: conns[0].Account, L2Connection: L2Connection{UUID: conns[0].UUID}}},
+		"Connections of remaining accounts are returned")
+}
+
+func TestClientPool_rateLimit(t *testing.T) {
+	//Given
+	server := newTestPoolServer()
+	defer server.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	pool := NewClientPool(ctx, WithPoolRateLimit(rate.NewLimiter(rate.Every(time.Hour), 2)))
+	pool.AddAccount("first", testPoolConfig(server, "first"))
+	pool.AddAccount("second", testPoolConfig(server, "second"))
+
+	//When
+	_, firstErr := pool.GetL2OutgoingConnections(nil)
+	_, secondErr := pool.GetL2OutgoingConnections(nil)
+
+	//Then
+	assert.Nil(t, firstErr, "Requests within limit should not return an error")
+	poolErr := PoolError{}
+	assert.True(t, errors.As(secondErr, &poolErr), "Error is a PoolError")
+	assert.Equal(t, 2, len(poolErr.Errors), "Limit is shared by accounts")
+}
+
+func TestClientPool_poolClientOptions(t *testing.T) {
+	//Given
+	server := newTestPoolServer()
+	defer server.Close()
+	pool := NewClientPool(context.Background(), WithPoolClientOptions(WithPageSize(7), WithTimeout(time.Minute)))
+
+	//When
+	err := pool.AddAccount("first", testPoolConfig(server, "first"))
+	client, clientErr := pool.Client("first")
+
+	//Then
+	assert.Nil(t, err, "Account is added")
+	assert.Nil(t, clientErr, "Account client is returned")
+	assert.Equal(t, 7, client.PageSize, "Pool page size is kept")
+	assert.Equal(t, time.Minute, client.GetClient().Timeout, "Pool timeout is kept")
+}
+
+func TestClientPool_errors(t *testing.T) {
+	//Given
+	server := newTestPoolServer()
+	defer server.Close()
+	pool := NewClientPool(context.Background())
+	pool.AddAccount("first", testPoolConfig(server, "first"))
+
+	//When
+	duplicateErr := pool.AddAccount("first", testPoolConfig(server, "first"))
+	noHeaderErr := pool.AddSubAccount("sub", "first", "123")
+	_, unknownErr := pool.Client("unknown")
+	pool.RemoveAccount("first")
+
+	//Then
+	assert.NotNil(t, duplicateErr, "Duplicated account should return an error")
+	assert.NotNil(t, noHeaderErr, "Sub-account without account header should return an error")
+	assert.NotNil(t, unknownErr, "Unknown account should return an error")
+	assert.Empty(t, pool.Accounts(), "Account is removed")
+}
+
+func TestClientPool_subAccountHTTPClientOptions(t *testing.T) {
+	//Given
+	server := newTestPoolServer()
+	defer server.Close()
+	pool := NewClientPool(context.Background(), WithPoolAccountHeader(testAccountHeader),
+		WithPoolClientOptions(WithTimeout(time.Minute)))
+	assert.Nil(t, pool.AddAccount("first", testPoolConfig(server, "first")), "Account is added")
+
+	//When
+	poolTimeoutErr := pool.AddSubAccount("first-sub", "first", "123")
+	timeoutErr := pool.AddSubAccount("second-sub", "first", "456", WithTimeout(time.Second))
+
+	//Then
+	assert.Nil(t, poolTimeoutErr, "Pool options should not be rejected for a sub-account")
+	assert.NotNil(t, timeoutErr, "Sub-account options that configure HTTP client should return an error")
+}
@@ -0,0 +1,108 @@
+//Package prometheus implements Prometheus adapter of Equinix Fabric client metrics
+package prometheus
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/equinix/ecx-go/v2"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	//LabelOperation is a name of label with client operation, i.e. GetL2Connection
+	LabelOperation = "operation"
+	//LabelStatus is a name of label with HTTP status code of a response.
+	//Status is "0" when no HTTP response was received
+	LabelStatus = "status"
+)
+
+//DefaultDurationBuckets are buckets of request duration histogram, in seconds
+var DefaultDurationBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+var _ ecx.Metrics = (*Metrics)(nil)
+
+//Metrics records requests made by Equinix Fabric client in Prometheus collectors.
+//Requests are counted in <namespace>_requests_total, failed ones in <namespace>_request_errors_total
+//and their durations are observed in <namespace>_request_duration_seconds histogram, all labelled
+//by operation and status. Retrievals of paginated collections are counted in
+//<namespace>_paginated_requests_total and their pages in <namespace>_pages_total, labelled by operation.
+//
+//Metrics is a prometheus.Collector and has to be registered, i.e. with prometheus.MustRegister
+type Metrics struct {
+	requests          *prometheus.CounterVec
+	errors            *prometheus.CounterVec
+	duration          *prometheus.HistogramVec
+	pages             *prometheus.CounterVec
+	paginatedRequests *prometheus.CounterVec
+}
+
+//NewMetrics creates Prometheus metrics with a given namespace, i.e. "ecx".
+//Duration histogram uses DefaultDurationBuckets when no buckets are given
+func NewMetrics(namespace string, buckets ...float64) *Metrics {
+	if len(buckets) == 0 {
+		buckets = DefaultDurationBuckets
+	}
+	return &Metrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "requests_total",
+			Help:      "Number of Equinix Fabric API requests.",
+		}, []string{LabelOperation, LabelStatus}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "request_errors_total",
+			Help:      "Number of failed Equinix Fabric API requests.",
+		}, []string{LabelOperation, LabelStatus}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "request_duration_seconds",
+			Help:      "Duration of Equinix Fabric API requests.",
+			Buckets:   buckets,
+		}, []string{LabelOperation, LabelStatus}),
+		pages: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "pages_total",
+			Help:      "Number of fetched pages of paginated Equinix Fabric API collections.",
+		}, []string{LabelOperation}),
+		paginatedRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "paginated_requests_total",
+			Help:      "Number of retrievals of paginated Equinix Fabric API collections.",
+		}, []string{LabelOperation}),
+	}
+}
+
+//Describe sends descriptors of all metrics to a given channel
+func (m *Metrics) Describe(ch chan<- *prometheus.Desc) {
+	for _, collector := range m.collectors() {
+		collector.Describe(ch)
+	}
+}
+
+//Collect sends values of all metrics to a given channel
+func (m *Metrics) Collect(ch chan<- prometheus.Metric) {
+	for _, collector := range m.collectors() {
+		collector.Collect(ch)
+	}
+}
+
+//ObserveRequest records finished request of a given client operation
+func (m *Metrics) ObserveRequest(operation string, statusCode int, duration time.Duration, err error) {
+	status := strconv.Itoa(statusCode)
+	m.requests.WithLabelValues(operation, status).Inc()
+	m.duration.WithLabelValues(operation, status).Observe(duration.Seconds())
+	if err != nil {
+		m.errors.WithLabelValues(operation, status).Inc()
+	}
+}
+
+//ObservePages records number of pages fetched by paginated collection retrieval
+func (m *Metrics) ObservePages(operation string, pages int) {
+	m.paginatedRequests.WithLabelValues(operation).Inc()
+	m.pages.WithLabelValues(operation).Add(float64(pages))
+}
+
+func (m *Metrics) collectors() []prometheus.Collector {
+	return []prometheus.Collector{m.requests, m.errors, m.duration, m.pages, m.paginatedRequests}
+}
@@ -0,0 +1,40 @@
+package prometheus
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetrics(t *testing.T) {
+	//Given
+	metrics := NewMetrics("ecx")
+	registry := prometheus.NewPedanticRegistry()
+	if err := registry.Register(metrics); err != nil {
+		assert.Failf(t, "Cannot register metrics due to %s", err.Error())
+	}
+
+	//When
+	metrics.ObserveRequest("GetL2Connection", 200, 100*time.Millisecond, nil)
+	metrics.ObserveRequest("GetL2Connection", 200, 300*time.Millisecond, nil)
+	metrics.ObserveRequest("GetL2Connection", 404, 50*time.Millisecond, errors.New("not found"))
+	metrics.ObserveRequest("GetUserPorts", 0, time.Second, errors.New("connection reset"))
+	metrics.ObservePages("GetL2OutgoingConnections", 3)
+	metrics.ObservePages("GetL2OutgoingConnections", 1)
+
+	//Then
+	assert.Equal(t, 2.0, testutil.ToFloat64(metrics.requests.WithLabelValues("GetL2Connection", "200")), "Successful requests match")
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.requests.WithLabelValues("GetL2Connection", "404")), "Failed requests match")
+	assert.Equal(t, 0.0, testutil.ToFloat64(metrics.errors.WithLabelValues("GetL2Connection", "200")), "No errors for successful requests")
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.errors.WithLabelValues("GetL2Connection", "404")), "Errors match")
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.errors.WithLabelValues("GetUserPorts", "0")), "Errors without response match")
+	assert.Equal(t, 4.0, testutil.ToFloat64(metrics.pages.WithLabelValues("GetL2OutgoingConnections")), "Pages match")
+	assert.Equal(t, 2.0, testutil.ToFloat64(metrics.paginatedRequests.WithLabelValues("GetL2OutgoingConnections")), "Paginated requests match")
+	count, err := testutil.GatherAndCount(registry, "ecx_request_duration_seconds")
+	assert.Nil(t, err, "Registry should not return an error")
+	assert.Equal(t, 3, count, "Duration histograms are labelled by operation and status")
+}
@@ -0,0 +1,263 @@
+package report
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/equinix/ecx-go/v2"
+)
+
+const (
+	//ColumnName is a name of connection, port or service profile
+	ColumnName = "name"
+	//ColumnUUID is an unique identifier of connection, port or service profile
+	ColumnUUID = "uuid"
+	//ColumnStatus is a status of connection or port
+	ColumnStatus = "status"
+	//ColumnProviderStatus is a provider status of connection
+	ColumnProviderStatus = "provider_status"
+	//ColumnSpeed is a speed of connection or port
+	ColumnSpeed = "speed"
+	//ColumnSpeedUnit is an unit of connection or port speed
+	ColumnSpeedUnit = "speed_unit"
+	//ColumnPort is an identifier of connection's a-side port
+	ColumnPort = "port"
+	//ColumnPortName is a name of connection's a-side port, resolved from joined ports
+	ColumnPortName = "port_name"
+	//ColumnPortMetro is a metro code of connection's a-side port, resolved from joined ports
+	ColumnPortMetro = "port_metro"
+	//ColumnDevice is an identifier of connection's a-side virtual device or port's device name
+	ColumnDevice = "device"
+	//ColumnDeviceInterface is an identifier of connection's a-side virtual device interface
+	ColumnDeviceInterface = "device_interface"
+	//ColumnVlanSTag is an a-side S-Tag of connection
+	ColumnVlanSTag = "vlan_s_tag"
+	//ColumnVlanCTag is an a-side C-Tag of connection
+	ColumnVlanCTag = "vlan_c_tag"
+	//ColumnZSidePort is an identifier of connection's z-side port
+	ColumnZSidePort = "zside_port"
+	//ColumnZSideVlanSTag is a z-side S-Tag of connection
+	ColumnZSideVlanSTag = "zside_vlan_s_tag"
+	//ColumnZSideVlanCTag is a z-side C-Tag of connection
+	ColumnZSideVlanCTag = "zside_vlan_c_tag"
+	//ColumnMetro is a seller metro code of connection or metro code of port
+	ColumnMetro = "metro"
+	//ColumnSellerRegion is a seller region of connection
+	ColumnSellerRegion = "seller_region"
+	//ColumnProfile is an identifier of connection's service profile
+	ColumnProfile = "profile"
+	//ColumnProfileName is a name of connection's service profile, resolved from joined profiles
+	ColumnProfileName = "profile_name"
+	//ColumnRedundancyType is a redundancy type of connection
+	ColumnRedundancyType = "redundancy_type"
+	//ColumnRedundancyGroup is a redundancy group of connection or port
+	ColumnRedundancyGroup = "redundancy_group"
+	//ColumnRedundantUUID is an identifier of connection's redundant connection
+	ColumnRedundantUUID = "redundant_uuid"
+	//ColumnPurchaseOrderNumber is a purchase order number of connection
+	ColumnPurchaseOrderNumber = "purchase_order_number"
+	//ColumnNotifications are notification emails of connection
+	ColumnNotifications = "notifications"
+	//ColumnNamedTag is a named tag of connection
+	ColumnNamedTag = "named_tag"
+	//ColumnAuthorizationKey is an authorization key of connection
+	ColumnAuthorizationKey = "authorization_key"
+	//ColumnAdditionalInfo are additional info entries of connection, written as key=value pairs
+	ColumnAdditionalInfo = "additional_info"
+	//ColumnOperationalStatus is an operational status of port
+	ColumnOperationalStatus = "operational_status"
+	//ColumnIBX is an IBX of port
+	ColumnIBX = "ibx"
+	//ColumnRegion is a region of port
+	ColumnRegion = "region"
+	//ColumnPriority is a priority of port within its redundancy group
+	ColumnPriority = "priority"
+	//ColumnEncapsulation is an encapsulation of port or service profile
+	ColumnEncapsulation = "encapsulation"
+	//ColumnLAG indicates if port is a LAG port
+	ColumnLAG = "lag"
+	//ColumnState is a state of service profile
+	ColumnState = "state"
+	//ColumnPrivate indicates if service profile is private
+	ColumnPrivate = "private"
+	//ColumnSpeedBands are speed bands of service profile
+	ColumnSpeedBands = "speed_bands"
+	//ColumnMetros are metro codes of service profile
+	ColumnMetros = "metros"
+	//ColumnPorts are identifiers of service profile ports
+	ColumnPorts = "ports"
+	//ColumnOrganizationName is an organization name of service profile
+	ColumnOrganizationName = "organization_name"
+)
+
+//DefaultConnectionColumns are columns of connections report used when no columns are selected
+var DefaultConnectionColumns = []string{ColumnName, ColumnUUID, ColumnStatus, ColumnProviderStatus,
+	ColumnSpeed, ColumnSpeedUnit, ColumnPort, ColumnVlanSTag, ColumnVlanCTag, ColumnMetro,
+	ColumnRedundancyGroup, ColumnPurchaseOrderNumber}
+
+//DefaultPortColumns are columns of ports report used when no columns are selected
+var DefaultPortColumns = []string{ColumnName, ColumnUUID, ColumnStatus, ColumnOperationalStatus,
+	ColumnSpeed, ColumnSpeedUnit, ColumnMetro, ColumnIBX, ColumnDevice, ColumnRedundancyGroup,
+	ColumnPriority}
+
+//DefaultServiceProfileColumns are columns of service profiles report used when no columns are selected
+var DefaultServiceProfileColumns = []string{ColumnName, ColumnUUID, ColumnState, ColumnPrivate,
+	ColumnSpeedBands, ColumnMetros, ColumnOrganizationName}
+
+type column struct {
+	name   string
+	header string
+	value  func(row interface{}) interface{}
+}
+
+type connectionRow struct {
+	conn    ecx.L2Connection
+	port    *ecx.Port
+	profile *ecx.L2ServiceProfile
+}
+
+var connectionColumns = []column{
+	{ColumnName, "Name", connectionValue(func(r connectionRow) interface{} { return stringCell(r.conn.Name) })},
+	{ColumnUUID, "UUID", connectionValue(func(r connectionRow) interface{} { return stringCell(r.conn.UUID) })},
+	{ColumnStatus, "Status", connectionValue(func(r connectionRow) interface{} { return stringCell(r.conn.Status) })},
+	{ColumnProviderStatus, "Provider status", connectionValue(func(r connectionRow) interface{} { return stringCell(r.conn.ProviderStatus) })},
+	{ColumnSpeed, "Speed", connectionValue(func(r connectionRow) interface{} { return intCell(r.conn.Speed) })},
+	{ColumnSpeedUnit, "Speed unit", connectionValue(func(r connectionRow) interface{} { return stringCell(r.conn.SpeedUnit) })},
+	{ColumnPort, "Port UUID", connectionValue(func(r connectionRow) interface{} { return stringCell(r.conn.PortUUID) })},
+	{ColumnPortName, "Port name", connectionValue(func(r connectionRow) interface{} {
+		if r.port == nil {
+			return nil
+		}
+		return stringCell(r.port.Name)
+	})},
+	{ColumnPortMetro, "Port metro", connectionValue(func(r connectionRow) interface{} {
+		if r.port == nil {
+			return nil
+		}
+		return stringCell(r.port.MetroCode)
+	})},
+	{ColumnDevice, "Device UUID", connectionValue(func(r connectionRow) interface{} { return stringCell(r.conn.DeviceUUID) })},
+	{ColumnDeviceInterface, "Device interface", connectionValue(func(r connectionRow) interface{} { return intCell(r.conn.DeviceInterfaceID) })},
+	{ColumnVlanSTag, "S-Tag", connectionValue(func(r connectionRow) interface{} { return intCell(r.conn.VlanSTag) })},
+	{ColumnVlanCTag, "C-Tag", connectionValue(func(r connectionRow) interface{} { return intCell(r.conn.VlanCTag) })},
+	{ColumnZSidePort, "Z-side port UUID", connectionValue(func(r connectionRow) interface{} { return stringCell(r.conn.ZSidePortUUID) })},
+	{ColumnZSideVlanSTag, "Z-side S-Tag", connectionValue(func(r connectionRow) interface{} { return intCell(r.conn.ZSideVlanSTag) })},
+	{ColumnZSideVlanCTag, "Z-side C-Tag", connectionValue(func(r connectionRow) interface{} { return intCell(r.conn.ZSideVlanCTag) })},
+	{ColumnMetro, "Metro", connectionValue(func(r connectionRow) interface{} { return stringCell(r.conn.SellerMetroCode) })},
+	{ColumnSellerRegion, "Seller region", connectionValue(func(r connectionRow) interface{} { return stringCell(r.conn.SellerRegion) })},
+	{ColumnProfile, "Profile UUID", connectionValue(func(r connectionRow) interface{} { return stringCell(r.conn.ProfileUUID) })},
+	{ColumnProfileName, "Profile name", connectionValue(func(r connectionRow) interface{} {
+		if r.profile == nil {
+			return nil
+		}
+		return stringCell(r.profile.Name)
+	})},
+	{ColumnRedundancyType, "Redundancy type", connectionValue(func(r connectionRow) interface{} { return stringCell(r.conn.RedundancyType) })},
+	{ColumnRedundancyGroup, "Redundancy group", connectionValue(func(r connectionRow) interface{} { return stringCell(r.conn.RedundancyGroup) })},
+	{ColumnRedundantUUID, "Redundant UUID", connectionValue(func(r connectionRow) interface{} { return stringCell(r.conn.RedundantUUID) })},
+	{ColumnPurchaseOrderNumber, "PO number", connectionValue(func(r connectionRow) interface{} { return stringCell(r.conn.PurchaseOrderNumber) })},
+	{ColumnNotifications, "Notifications", connectionValue(func(r connectionRow) interface{} { return listCell(r.conn.Notifications) })},
+	{ColumnNamedTag, "Named tag", connectionValue(func(r connectionRow) interface{} { return stringCell(r.conn.NamedTag) })},
+	{ColumnAuthorizationKey, "Authorization key", connectionValue(func(r connectionRow) interface{} { return stringCell(r.conn.AuthorizationKey) })},
+	{ColumnAdditionalInfo, "Additional info", connectionValue(func(r connectionRow) interface{} {
+		infos := make([]string, len(r.conn.AdditionalInfo))
+		for i := range r.conn.AdditionalInfo {
+			infos[i] = ecx.StringValue(r.conn.AdditionalInfo[i].Name) + "=" + ecx.StringValue(r.conn.AdditionalInfo[i].Value)
+		}
+		return listCell(infos)
+	})},
+}
+
+var portColumns = []column{
+	{ColumnName, "Name", portValue(func(p ecx.Port) interface{} { return stringCell(p.Name) })},
+	{ColumnUUID, "UUID", portValue(func(p ecx.Port) interface{} { return stringCell(p.UUID) })},
+	{ColumnStatus, "Status", portValue(func(p ecx.Port) interface{} { return stringCell(p.Status) })},
+	{ColumnOperationalStatus, "Operational status", portValue(func(p ecx.Port) interface{} { return stringCell(p.OperationalStatus) })},
+	{ColumnSpeed, "Speed", portValue(func(p ecx.Port) interface{} { return intCell(p.Speed) })},
+	{ColumnSpeedUnit, "Speed unit", portValue(func(p ecx.Port) interface{} { return stringCell(p.SpeedUnit) })},
+	{ColumnMetro, "Metro", portValue(func(p ecx.Port) interface{} { return stringCell(p.MetroCode) })},
+	{ColumnIBX, "IBX", portValue(func(p ecx.Port) interface{} { return stringCell(p.IBX) })},
+	{ColumnRegion, "Region", portValue(func(p ecx.Port) interface{} { return stringCell(p.Region) })},
+	{ColumnDevice, "Device", portValue(func(p ecx.Port) interface{} { return stringCell(p.Device) })},
+	{ColumnRedundancyGroup, "Redundancy group", portValue(func(p ecx.Port) interface{} { return stringCell(p.RedundancyGroup) })},
+	{ColumnPriority, "Priority", portValue(func(p ecx.Port) interface{} { return stringCell(p.Priority) })},
+	{ColumnEncapsulation, "Encapsulation", portValue(func(p ecx.Port) interface{} { return stringCell(p.Encapsulation) })},
+	{ColumnLAG, "LAG", portValue(func(p ecx.Port) interface{} { return boolCell(p.IsLAG) })},
+}
+
+var serviceProfileColumns = []column{
+	{ColumnName, "Name", profileValue(func(p ecx.L2ServiceProfile) interface{} { return stringCell(p.Name) })},
+	{ColumnUUID, "UUID", profileValue(func(p ecx.L2ServiceProfile) interface{} { return stringCell(p.UUID) })},
+	{ColumnState, "State", profileValue(func(p ecx.L2ServiceProfile) interface{} { return stringCell(p.State) })},
+	{ColumnPrivate, "Private", profileValue(func(p ecx.L2ServiceProfile) interface{} { return boolCell(p.Private) })},
+	{ColumnEncapsulation, "Encapsulation", profileValue(func(p ecx.L2ServiceProfile) interface{} { return stringCell(p.Encapsulation) })},
+	{ColumnSpeedBands, "Speed bands", profileValue(func(p ecx.L2ServiceProfile) interface{} {
+		bands := make([]string, len(p.SpeedBands))
+		for i := range p.SpeedBands {
+			bands[i] = strconv.Itoa(ecx.IntValue(p.SpeedBands[i].Speed)) + ecx.StringValue(p.SpeedBands[i].SpeedUnit)
+		}
+		return listCell(bands)
+	})},
+	{ColumnMetros, "Metros", profileValue(func(p ecx.L2ServiceProfile) interface{} {
+		metros := make([]string, len(p.Metros))
+		for i := range p.Metros {
+			metros[i] = ecx.StringValue(p.Metros[i].Code)
+		}
+		return listCell(metros)
+	})},
+	{ColumnPorts, "Ports", profileValue(func(p ecx.L2ServiceProfile) interface{} {
+		ports := make([]string, len(p.Ports))
+		for i := range p.Ports {
+			ports[i] = ecx.StringValue(p.Ports[i].ID)
+		}
+		return listCell(ports)
+	})},
+	{ColumnOrganizationName, "Organization name", profileValue(func(p ecx.L2ServiceProfile) interface{} { return stringCell(p.OrganizationName) })},
+}
+
+func connectionValue(f func(r connectionRow) interface{}) func(row interface{}) interface{} {
+	return func(row interface{}) interface{} {
+		return f(row.(connectionRow))
+	}
+}
+
+func portValue(f func(p ecx.Port) interface{}) func(row interface{}) interface{} {
+	return func(row interface{}) interface{} {
+		return f(row.(ecx.Port))
+	}
+}
+
+func profileValue(f func(p ecx.L2ServiceProfile) interface{}) func(row interface{}) interface{} {
+	return func(row interface{}) interface{} {
+		return f(row.(ecx.L2ServiceProfile))
+	}
+}
+
+func stringCell(s *string) interface{} {
+	if s == nil {
+		return nil
+	}
+	return *s
+}
+
+func intCell(i *int) interface{} {
+	if i == nil {
+		return nil
+	}
+	return *i
+}
+
+func boolCell(b *bool) interface{} {
+	if b == nil {
+		return nil
+	}
+	return *b
+}
+
+//listCell joins list values with semicolons, as commas are used as CSV separator
+func listCell(values []string) interface{} {
+	if len(values) == 0 {
+		return nil
+	}
+	return strings.Join(values, ";")
+}
@@ -0,0 +1,511 @@
+package report
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/equinix/ecx-go/v2"
+)
+
+const (
+	//RedundancyTypePrimary marks imported row as primary connection of a redundancy group
+	RedundancyTypePrimary = "PRIMARY"
+	//RedundancyTypeSecondary marks imported row as secondary connection of a redundancy group
+	RedundancyTypeSecondary = "SECONDARY"
+
+	maxImportLineSize = 1 << 20
+)
+
+//ConnectionSpec describes connection requirements imported from CSV.
+//Secondary is set for redundant connections, defined by two rows with the same
+//redundancy group. Line is a line number of primary connection's row
+type ConnectionSpec struct {
+	Line      int
+	Primary   ecx.L2Connection
+	Secondary *ecx.L2Connection
+}
+
+//ImportResolver resolves port and service profile names used in imported rows
+//to their UUIDs. ecx.Client implements this interface
+type ImportResolver interface {
+	GetUserPorts() ([]ecx.Port, error)
+	GetL2SellerProfiles() ([]ecx.L2ServiceProfile, error)
+}
+
+//RowError describes problem with a given line and, optionally, column of imported CSV
+type RowError struct {
+	Line   int
+	Column string
+	Err    error
+}
+
+//Error returns string representation of row error
+func (e RowError) Error() string {
+	if e.Column == "" {
+		return fmt.Sprintf("line %d: %s", e.Line, e.Err)
+	}
+	return fmt.Sprintf("line %d: column %q: %s", e.Line, e.Column, e.Err)
+}
+
+//ImportError describes all row errors found during import
+type ImportError struct {
+	Rows []RowError
+}
+
+//Error returns string representation of import error
+func (e ImportError) Error() string {
+	msgs := make([]string, len(e.Rows))
+	for i := range e.Rows {
+		msgs[i] = e.Rows[i].Error()
+	}
+	return fmt.Sprintf("import failed with %d error(s): %s", len(e.Rows), strings.Join(msgs, "; "))
+}
+
+//newImportError creates import error with row errors ordered by line
+func newImportError(errs []RowError) ImportError {
+	sort.SliceStable(errs, func(i, j int) bool {
+		return errs[i].Line < errs[j].Line
+	})
+	return ImportError{Rows: errs}
+}
+
+type importRow struct {
+	line           int
+	conn           ecx.L2Connection
+	portName       string
+	profileName    string
+	group          string
+	redundancyType string
+	secondary      bool
+	malformed      bool
+}
+
+type importSetter func(row *importRow, value string) error
+
+type importField struct {
+	column string
+	set    importSetter
+}
+
+var importSetters = map[string]importSetter{
+	ColumnName:                func(r *importRow, v string) error { r.conn.Name = ecx.String(v); return nil },
+	ColumnSpeed:               func(r *importRow, v string) error { return parseImportInt(v, &r.conn.Speed) },
+	ColumnSpeedUnit:           setImportSpeedUnit,
+	ColumnPort:                func(r *importRow, v string) error { r.conn.PortUUID = ecx.String(v); return nil },
+	ColumnPortName:            func(r *importRow, v string) error { r.portName = v; return nil },
+	ColumnDevice:              func(r *importRow, v string) error { r.conn.DeviceUUID = ecx.String(v); return nil },
+	ColumnDeviceInterface:     func(r *importRow, v string) error { return parseImportInt(v, &r.conn.DeviceInterfaceID) },
+	ColumnVlanSTag:            func(r *importRow, v string) error { return parseImportVlan(v, &r.conn.VlanSTag) },
+	ColumnVlanCTag:            func(r *importRow, v string) error { return parseImportVlan(v, &r.conn.VlanCTag) },
+	ColumnZSidePort:           func(r *importRow, v string) error { r.conn.ZSidePortUUID = ecx.String(v); return nil },
+	ColumnZSideVlanSTag:       func(r *importRow, v string) error { return parseImportVlan(v, &r.conn.ZSideVlanSTag) },
+	ColumnZSideVlanCTag:       func(r *importRow, v string) error { return parseImportVlan(v, &r.conn.ZSideVlanCTag) },
+	ColumnMetro:               func(r *importRow, v string) error { r.conn.SellerMetroCode = ecx.String(v); return nil },
+	ColumnSellerRegion:        func(r *importRow, v string) error { r.conn.SellerRegion = ecx.String(v); return nil },
+	ColumnProfile:             func(r *importRow, v string) error { r.conn.ProfileUUID = ecx.String(v); return nil },
+	ColumnProfileName:         func(r *importRow, v string) error { r.profileName = v; return nil },
+	ColumnRedundancyType:      setImportRedundancyType,
+	ColumnRedundancyGroup:     func(r *importRow, v string) error { r.group = v; return nil },
+	ColumnPurchaseOrderNumber: func(r *importRow, v string) error { r.conn.PurchaseOrderNumber = ecx.String(v); return nil },
+	ColumnNotifications:       setImportNotifications,
+	ColumnNamedTag:            func(r *importRow, v string) error { r.conn.NamedTag = ecx.String(v); return nil },
+	ColumnAuthorizationKey:    func(r *importRow, v string) error { r.conn.AuthorizationKey = ecx.String(v); return nil },
+	ColumnAdditionalInfo:      addImportAdditionalInfo,
+}
+
+//importIgnoredColumns are read-only report columns, ignored on import so
+//connections report can be imported back
+var importIgnoredColumns = map[string]struct{}{
+	ColumnUUID:           {},
+	ColumnStatus:         {},
+	ColumnProviderStatus: {},
+	ColumnPortMetro:      {},
+	ColumnRedundantUUID:  {},
+}
+
+//ImportConnections parses connection requirements from CSV with a header row.
+//Header cells are report column names or headers, i.e. "port_name" or "Port name".
+//Column additional_info holds semicolon separated key=value pairs and may be repeated.
+//Two rows with the same redundancy_group define redundant connection, primary row
+//is selected with redundancy_type column or, when not given, by row order.
+//
+//All rows are validated before resolver is used to resolve port and profile names.
+//Validation and resolution problems are returned as ImportError with line numbers
+func ImportConnections(r io.Reader, resolver ImportResolver) ([]ConnectionSpec, error) {
+	records, err := readImportRecords(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, errors.New("import data has no header row")
+	}
+	fields, rowErrs := parseImportHeader(records[0])
+	if len(rowErrs) > 0 {
+		return nil, ImportError{Rows: rowErrs}
+	}
+	rows := make([]*importRow, 0, len(records)-1)
+	for _, record := range records[1:] {
+		row, errs := parseImportRow(record, fields)
+		rowErrs = append(rowErrs, errs...)
+		rows = append(rows, row)
+	}
+	groups, errs := groupImportRows(rows)
+	rowErrs = append(rowErrs, errs...)
+	for _, row := range rows {
+		rowErrs = append(rowErrs, validateImportDestination(row)...)
+	}
+	if len(rowErrs) > 0 {
+		return nil, newImportError(rowErrs)
+	}
+	if errs, err := resolveImportNames(rows, resolver); err != nil {
+		return nil, err
+	} else if len(errs) > 0 {
+		return nil, newImportError(errs)
+	}
+	return buildConnectionSpecs(rows, groups), nil
+}
+
+type importRecord struct {
+	line   int
+	fields []string
+}
+
+//readImportRecords reads CSV records along with line numbers they start at.
+//Lines are accumulated until quotes are balanced, so quoted fields may span lines
+func readImportRecords(r io.Reader) ([]importRecord, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxImportLineSize)
+	var records []importRecord
+	var chunk strings.Builder
+	line, start := 0, 0
+	for scanner.Scan() {
+		line++
+		text := scanner.Text()
+		if line == 1 {
+			text = strings.TrimPrefix(text, "\ufeff")
+		}
+		if chunk.Len() == 0 {
+			if strings.TrimSpace(text) == "" {
+				continue
+			}
+			start = line
+		} else {
+			chunk.WriteByte('\n')
+		}
+		chunk.WriteString(text)
+		if strings.Count(chunk.String(), `"`)%2 != 0 {
+			continue
+		}
+		fields, err := csv.NewReader(strings.NewReader(chunk.String())).Read()
+		if err != nil {
+			return nil, ImportError{Rows: []RowError{{Line: start, Err: err}}}
+		}
+		records = append(records, importRecord{line: start, fields: fields})
+		chunk.Reset()
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if chunk.Len() > 0 {
+		return nil, ImportError{Rows: []RowError{{Line: start, Err: errors.New("quoted field is not terminated")}}}
+	}
+	return records, nil
+}
+
+func parseImportHeader(header importRecord) ([]importField, []RowError) {
+	byHeader := make(map[string]string, len(connectionColumns))
+	for _, col := range connectionColumns {
+		byHeader[strings.ToLower(col.header)] = col.name
+	}
+	fields := make([]importField, len(header.fields))
+	var errs []RowError
+	for i, field := range header.fields {
+		name := strings.ToLower(strings.TrimSpace(field))
+		if colName, ok := byHeader[name]; ok {
+			name = colName
+		}
+		if _, ok := importIgnoredColumns[name]; ok {
+			continue
+		}
+		setter, ok := importSetters[name]
+		if !ok {
+			errs = append(errs, RowError{Line: header.line, Column: field, Err: errors.New("unknown column")})
+			continue
+		}
+		if name != ColumnAdditionalInfo && hasImportSetter(header.fields[:i], name, byHeader) {
+			errs = append(errs, RowError{Line: header.line, Column: field, Err: errors.New("duplicated column")})
+			continue
+		}
+		fields[i] = importField{column: name, set: setter}
+	}
+	return fields, errs
+}
+
+func hasImportSetter(fields []string, name string, byHeader map[string]string) bool {
+	for _, field := range fields {
+		other := strings.ToLower(strings.TrimSpace(field))
+		if colName, ok := byHeader[other]; ok {
+			other = colName
+		}
+		if other == name {
+			return true
+		}
+	}
+	return false
+}
+
+func parseImportRow(record importRecord, fields []importField) (*importRow, []RowError) {
+	row := &importRow{line: record.line}
+	if len(record.fields) != len(fields) {
+		row.malformed = true
+		return row, []RowError{{Line: record.line, Err: fmt.Errorf("expected %d fields, got %d", len(fields), len(record.fields))}}
+	}
+	var errs []RowError
+	for i, field := range record.fields {
+		value := strings.TrimSpace(field)
+		if fields[i].set == nil || value == "" {
+			continue
+		}
+		if err := fields[i].set(row, value); err != nil {
+			errs = append(errs, RowError{Line: record.line, Column: fields[i].column, Err: err})
+		}
+	}
+	for _, err := range validateImportRow(row) {
+		if !hasImportColumnError(errs, err.Column) {
+			errs = append(errs, err)
+		}
+	}
+	return row, errs
+}
+
+func validateImportRow(row *importRow) []RowError {
+	var errs []RowError
+	required := func(column string, set bool) {
+		if !set {
+			errs = append(errs, RowError{Line: row.line, Column: column, Err: errors.New("value is required")})
+		}
+	}
+	required(ColumnName, row.conn.Name != nil)
+	required(ColumnSpeed, row.conn.Speed != nil)
+	required(ColumnSpeedUnit, row.conn.SpeedUnit != nil)
+	aSides := 0
+	for _, set := range []bool{row.conn.PortUUID != nil, row.portName != "", row.conn.DeviceUUID != nil} {
+		if set {
+			aSides++
+		}
+	}
+	if aSides != 1 {
+		errs = append(errs, RowError{Line: row.line, Err: fmt.Errorf("exactly one of %s, %s or %s is required", ColumnPort, ColumnPortName, ColumnDevice)})
+	}
+	if row.conn.ProfileUUID != nil && row.profileName != "" {
+		errs = append(errs, RowError{Line: row.line, Err: fmt.Errorf("only one of %s or %s is allowed", ColumnProfile, ColumnProfileName)})
+	}
+	return errs
+}
+
+func hasImportColumnError(errs []RowError, column string) bool {
+	for _, err := range errs {
+		if column != "" && err.Column == column {
+			return true
+		}
+	}
+	return false
+}
+
+//validateImportDestination checks if connection's z-side is defined. Secondary
+//connection of redundancy group may use primary connection's z-side
+func validateImportDestination(row *importRow) []RowError {
+	if row.malformed || row.secondary || row.conn.ProfileUUID != nil || row.profileName != "" || row.conn.ZSidePortUUID != nil {
+		return nil
+	}
+	return []RowError{{Line: row.line, Err: fmt.Errorf("one of %s, %s or %s is required", ColumnProfile, ColumnProfileName, ColumnZSidePort)}}
+}
+
+//groupImportRows pairs rows with the same redundancy group. Returned map holds
+//primary and secondary row, in that order, for each group
+func groupImportRows(rows []*importRow) (map[string][]*importRow, []RowError) {
+	groups := make(map[string][]*importRow)
+	var order []string
+	var errs []RowError
+	for _, row := range rows {
+		if row.group == "" {
+			if row.redundancyType != "" {
+				errs = append(errs, RowError{Line: row.line, Column: ColumnRedundancyType, Err: fmt.Errorf("value requires %s", ColumnRedundancyGroup)})
+			}
+			continue
+		}
+		if _, ok := groups[row.group]; !ok {
+			order = append(order, row.group)
+		}
+		groups[row.group] = append(groups[row.group], row)
+	}
+	for _, group := range order {
+		members := groups[group]
+		if len(members) != 2 {
+			errs = append(errs, RowError{Line: members[0].line, Column: ColumnRedundancyGroup,
+				Err: fmt.Errorf("group %q needs exactly two rows, got %d", group, len(members))})
+			continue
+		}
+		first, second := members[0], members[1]
+		if first.redundancyType == RedundancyTypeSecondary || second.redundancyType == RedundancyTypePrimary {
+			first, second = second, first
+		}
+		if first.redundancyType == RedundancyTypeSecondary || second.redundancyType == RedundancyTypePrimary {
+			errs = append(errs, RowError{Line: members[1].line, Column: ColumnRedundancyType,
+				Err: fmt.Errorf("group %q needs one %s and one %s row", group, RedundancyTypePrimary, RedundancyTypeSecondary)})
+			continue
+		}
+		second.secondary = true
+		groups[group] = []*importRow{first, second}
+	}
+	return groups, errs
+}
+
+func resolveImportNames(rows []*importRow, resolver ImportResolver) ([]RowError, error) {
+	var portNames, profileNames bool
+	for _, row := range rows {
+		portNames = portNames || row.portName != ""
+		profileNames = profileNames || row.profileName != ""
+	}
+	var errs []RowError
+	if portNames {
+		ports, err := resolver.GetUserPorts()
+		if err != nil {
+			return nil, err
+		}
+		byName := make(map[string][]*string)
+		for _, port := range ports {
+			byName[ecx.StringValue(port.Name)] = append(byName[ecx.StringValue(port.Name)], port.UUID)
+		}
+		for _, row := range rows {
+			if row.portName == "" {
+				continue
+			}
+			uuid, err := resolveImportName(byName, "port", row.portName)
+			if err != nil {
+				errs = append(errs, RowError{Line: row.line, Column: ColumnPortName, Err: err})
+			}
+			row.conn.PortUUID = uuid
+		}
+	}
+	if profileNames {
+		profiles, err := resolver.GetL2SellerProfiles()
+		if err != nil {
+			return nil, err
+		}
+		byName := make(map[string][]*string)
+		for _, profile := range profiles {
+			byName[ecx.StringValue(profile.Name)] = append(byName[ecx.StringValue(profile.Name)], profile.UUID)
+		}
+		for _, row := range rows {
+			if row.profileName == "" {
+				continue
+			}
+			uuid, err := resolveImportName(byName, "service profile", row.profileName)
+			if err != nil {
+				errs = append(errs, RowError{Line: row.line, Column: ColumnProfileName, Err: err})
+			}
+			row.conn.ProfileUUID = uuid
+		}
+	}
+	return errs, nil
+}
+
+func resolveImportName(byName map[string][]*string, kind string, name string) (*string, error) {
+	uuids := byName[name]
+	switch len(uuids) {
+	case 0:
+		return nil, fmt.Errorf("%s %q not found", kind, name)
+	case 1:
+		return uuids[0], nil
+	}
+	return nil, fmt.Errorf("%s name %q is ambiguous, found %d matches", kind, name, len(uuids))
+}
+
+func buildConnectionSpecs(rows []*importRow, groups map[string][]*importRow) []ConnectionSpec {
+	specs := make([]ConnectionSpec, 0, len(rows))
+	done := make(map[string]bool)
+	for _, row := range rows {
+		if row.group == "" {
+			specs = append(specs, ConnectionSpec{Line: row.line, Primary: row.conn})
+			continue
+		}
+		if done[row.group] {
+			continue
+		}
+		done[row.group] = true
+		primary, secondary := groups[row.group][0], groups[row.group][1]
+		secondaryConn := secondary.conn
+		specs = append(specs, ConnectionSpec{Line: primary.line, Primary: primary.conn, Secondary: &secondaryConn})
+	}
+	return specs
+}
+
+func parseImportInt(value string, target **int) error {
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		return fmt.Errorf("invalid number %q", value)
+	}
+	*target = ecx.Int(i)
+	return nil
+}
+
+func parseImportVlan(value string, target **int) error {
+	if err := parseImportInt(value, target); err != nil {
+		return err
+	}
+	if **target < ecx.VlanTagMin || **target > ecx.VlanTagMax {
+		return fmt.Errorf("VLAN tag %d is out of range %d-%d", **target, ecx.VlanTagMin, ecx.VlanTagMax)
+	}
+	return nil
+}
+
+func setImportSpeedUnit(row *importRow, value string) error {
+	unit := strings.ToUpper(value)
+	if unit != "MB" && unit != "GB" {
+		return fmt.Errorf("unsupported speed unit %q", value)
+	}
+	row.conn.SpeedUnit = ecx.String(unit)
+	return nil
+}
+
+func setImportRedundancyType(row *importRow, value string) error {
+	redundancyType := strings.ToUpper(value)
+	if redundancyType != RedundancyTypePrimary && redundancyType != RedundancyTypeSecondary {
+		return fmt.Errorf("unsupported redundancy type %q", value)
+	}
+	row.redundancyType = redundancyType
+	return nil
+}
+
+func setImportNotifications(row *importRow, value string) error {
+	for _, email := range strings.Split(value, ";") {
+		if email = strings.TrimSpace(email); email != "" {
+			row.conn.Notifications = append(row.conn.Notifications, email)
+		}
+	}
+	return nil
+}
+
+func addImportAdditionalInfo(row *importRow, value string) error {
+	for _, pair := range strings.Split(value, ";") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 || strings.TrimSpace(kv[0]) == "" {
+			return fmt.Errorf("additional info %q is not a key=value pair", pair)
+		}
+		row.conn.AdditionalInfo = append(row.conn.AdditionalInfo, ecx.L2ConnectionAdditionalInfo{
+			Name:  ecx.String(strings.TrimSpace(kv[0])),
+			Value: ecx.String(strings.TrimSpace(kv[1])),
+		})
+	}
+	return nil
+}
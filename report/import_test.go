@@ -0,0 +1,221 @@
+package report
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/equinix/ecx-go/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockImportResolver struct {
+	ports         []ecx.Port
+	profiles      []ecx.L2ServiceProfile
+	portCalls     int
+	profileCalls  int
+	resolverError error
+}
+
+func (m *mockImportResolver) GetUserPorts() ([]ecx.Port, error) {
+	m.portCalls++
+	return m.ports, m.resolverError
+}
+
+func (m *mockImportResolver) GetL2SellerProfiles() ([]ecx.L2ServiceProfile, error) {
+	m.profileCalls++
+	return m.profiles, m.resolverError
+}
+
+func newMockImportResolver() *mockImportResolver {
+	return &mockImportResolver{
+		ports: testPorts,
+		profiles: []ecx.L2ServiceProfile{
+			{UUID: ecx.String("profile-1"), Name: ecx.String("AWS Direct Connect")},
+			{UUID: ecx.String("profile-2"), Name: ecx.String("Duplicate")},
+			{UUID: ecx.String("profile-3"), Name: ecx.String("Duplicate")},
+		},
+	}
+}
+
+func TestImportConnections(t *testing.T) {
+	//Given
+	data := "\ufeffname,speed,speed_unit,port_name,vlan_s_tag,profile_name,metro,notifications,additional_info,additional_info,redundancy_group,redundancy_type\n" +
+		"single,50,MB,port-one,100,AWS Direct Connect,SV,john@example.com;marry@example.com,account=123456789012,,,\n" +
+		"\n" +
+		"\"redundant,\nsecondary\",1,GB,port-two,201,,SV,,,,group-1,SECONDARY\n" +
+		"redundant-primary,1,gb,port-one,200,AWS Direct Connect,SV,,asn=65000;global=false,vlan=200,group-1,PRIMARY\n"
+	resolver := newMockImportResolver()
+
+	//When
+	specs, err := ImportConnections(strings.NewReader(data), resolver)
+
+	//Then
+	assert.Nil(t, err, "Import should not return an error")
+	assert.Equal(t, 2, len(specs), "Number of specs matches")
+	assert.Equal(t, 1, resolver.portCalls, "Ports are fetched once")
+	assert.Equal(t, 1, resolver.profileCalls, "Profiles are fetched once")
+
+	single := specs[0]
+	assert.Equal(t, 2, single.Line, "Single connection line matches")
+	assert.Nil(t, single.Secondary, "Single connection has no secondary")
+	assert.Equal(t, "single", ecx.StringValue(single.Primary.Name), "Name matches")
+	assert.Equal(t, 50, ecx.IntValue(single.Primary.Speed), "Speed matches")
+	assert.Equal(t, "MB", ecx.StringValue(single.Primary.SpeedUnit), "SpeedUnit matches")
+	assert.Equal(t, "port-1", ecx.StringValue(single.Primary.PortUUID), "Port name is resolved")
+	assert.Equal(t, "profile-1", ecx.StringValue(single.Primary.ProfileUUID), "Profile name is resolved")
+	assert.Equal(t, 100, ecx.IntValue(single.Primary.VlanSTag), "VlanSTag matches")
+	assert.Equal(t, []string{"john@example.com", "marry@example.com"}, single.Primary.Notifications, "Notifications match")
+	assert.Equal(t, []ecx.L2ConnectionAdditionalInfo{{Name: ecx.String("account"), Value: ecx.String("123456789012")}},
+		single.Primary.AdditionalInfo, "AdditionalInfo matches")
+
+	redundant := specs[1]
+	assert.Equal(t, 6, redundant.Line, "Redundant connection line is primary row's line")
+	assert.Equal(t, "redundant-primary", ecx.StringValue(redundant.Primary.Name), "Primary name matches")
+	assert.Equal(t, "GB", ecx.StringValue(redundant.Primary.SpeedUnit), "Primary SpeedUnit is normalized")
+	assert.Equal(t, 3, len(redundant.Primary.AdditionalInfo), "Primary AdditionalInfo from repeated columns")
+	assert.NotNil(t, redundant.Secondary, "Redundant connection has secondary")
+	assert.Equal(t, "redundant,\nsecondary", ecx.StringValue(redundant.Secondary.Name), "Secondary name matches")
+	assert.Equal(t, "port-2", ecx.StringValue(redundant.Secondary.PortUUID), "Secondary port name is resolved")
+	assert.Equal(t, 201, ecx.IntValue(redundant.Secondary.VlanSTag), "Secondary VlanSTag matches")
+}
+
+func TestImportConnections_validationErrors(t *testing.T) {
+	//Given
+	data := "name,speed,speed_unit,port,port_name,vlan_s_tag,profile,redundancy_group\n" +
+		"ok,50,MB,port-1,,100,profile-1,\n" +
+		",abc,TB,port-1,,5000,profile-1,\n" +
+		"two-a-sides,50,MB,port-1,port-one,100,profile-1,\n" +
+		"no-z-side,50,MB,port-1,,100,,\n" +
+		"lonely,50,MB,port-1,,100,profile-1,group-1\n" +
+		"short,50\n"
+	resolver := newMockImportResolver()
+
+	//When
+	specs, err := ImportConnections(strings.NewReader(data), resolver)
+
+	//Then
+	assert.Nil(t, specs, "Import should not return specs")
+	importErr := ImportError{}
+	assert.True(t, errors.As(err, &importErr), "Error is an ImportError")
+	assert.Equal(t, []RowError{
+		{Line: 3, Column: ColumnSpeed, Err: importErr.Rows[0].Err},
+		{Line: 3, Column: ColumnSpeedUnit, Err: importErr.Rows[1].Err},
+		{Line: 3, Column: ColumnVlanSTag, Err: importErr.Rows[2].Err},
+		{Line: 3, Column: ColumnName, Err: importErr.Rows[3].Err},
+		{Line: 4, Err: importErr.Rows[4].Err},
+		{Line: 5, Err: importErr.Rows[5].Err},
+		{Line: 6, Column: ColumnRedundancyGroup, Err: importErr.Rows[6].Err},
+		{Line: 7, Err: importErr.Rows[7].Err},
+	}, importErr.Rows, "Row errors match")
+	assert.Equal(t, 0, resolver.portCalls, "Ports are not fetched")
+	assert.Equal(t, 0, resolver.profileCalls, "Profiles are not fetched")
+}
+
+func TestImportConnections_unknownColumn(t *testing.T) {
+	//Given
+	data := "name,speed,colour\n"
+
+	//When
+	_, err := ImportConnections(strings.NewReader(data), newMockImportResolver())
+
+	//Then
+	importErr := ImportError{}
+	assert.True(t, errors.As(err, &importErr), "Error is an ImportError")
+	assert.Equal(t, 1, len(importErr.Rows), "Number of row errors matches")
+	assert.Equal(t, 1, importErr.Rows[0].Line, "Line matches")
+	assert.Equal(t, "colour", importErr.Rows[0].Column, "Column matches")
+}
+
+func TestImportConnections_resolutionErrors(t *testing.T) {
+	//Given
+	data := "name,speed,speed_unit,port_name,profile_name\n" +
+		"unknown-port,50,MB,port-three,AWS Direct Connect\n" +
+		"ambiguous-profile,50,MB,port-one,Duplicate\n"
+
+	//When
+	_, err := ImportConnections(strings.NewReader(data), newMockImportResolver())
+
+	//Then
+	importErr := ImportError{}
+	assert.True(t, errors.As(err, &importErr), "Error is an ImportError")
+	assert.Equal(t, 2, len(importErr.Rows), "Number of row errors matches")
+	assert.Equal(t, RowError{Line: 2, Column: ColumnPortName, Err: importErr.Rows[0].Err}, importErr.Rows[0], "Port error matches")
+	assert.Equal(t, RowError{Line: 3, Column: ColumnProfileName, Err: importErr.Rows[1].Err}, importErr.Rows[1], "Profile error matches")
+}
+
+func TestImportConnections_resolverFailure(t *testing.T) {
+	//Given
+	data := "name,speed,speed_unit,port_name,profile\n" +
+		"conn,50,MB,port-one,profile-1\n"
+	resolver := newMockImportResolver()
+	resolver.resolverError = errors.New("boom")
+
+	//When
+	specs, err := ImportConnections(strings.NewReader(data), resolver)
+
+	//Then
+	assert.Nil(t, specs, "Import should not return specs")
+	assert.Equal(t, resolver.resolverError, err, "Resolver error is returned")
+}
+
+func TestImportConnections_reportRoundTrip(t *testing.T) {
+	//Given
+	conns := []ecx.L2Connection{
+		{
+			UUID:           ecx.String("uuid-1"),
+			Name:           ecx.String("conn"),
+			Status:         ecx.String(ecx.ConnectionStatusProvisioned),
+			Speed:          ecx.Int(50),
+			SpeedUnit:      ecx.String("MB"),
+			PortUUID:       ecx.String("port-1"),
+			VlanSTag:       ecx.Int(100),
+			ProfileUUID:    ecx.String("profile-1"),
+			Notifications:  []string{"john@example.com"},
+			AdditionalInfo: []ecx.L2ConnectionAdditionalInfo{{Name: ecx.String("asn"), Value: ecx.String("65000")}},
+		},
+	}
+	table, err := Connections(conns, Joins{}, ColumnUUID, ColumnName, ColumnStatus, ColumnSpeed, ColumnSpeedUnit,
+		ColumnPort, ColumnVlanSTag, ColumnProfile, ColumnNotifications, ColumnAdditionalInfo)
+	if err != nil {
+		assert.Failf(t, "Cannot create report due to %s", err.Error())
+	}
+	buf := &bytes.Buffer{}
+	if err := table.WriteCSV(buf); err != nil {
+		assert.Failf(t, "Cannot write report due to %s", err.Error())
+	}
+
+	//When
+	specs, err := ImportConnections(buf, newMockImportResolver())
+
+	//Then
+	assert.Nil(t, err, "Import should not return an error")
+	expected := conns[0]
+	expected.UUID = nil
+	expected.Status = nil
+	assert.Equal(t, []ConnectionSpec{{Line: 2, Primary: expected}}, specs, "Imported connection matches reported one")
+}
+
+func TestParseImportVlan(t *testing.T) {
+	//Given
+	values := map[string]bool{
+		"1":    false,
+		"2":    true,
+		"4094": true,
+		"4095": false,
+	}
+	for value, valid := range values {
+		var tag *int
+
+		//When
+		err := parseImportVlan(value, &tag)
+
+		//Then
+		if valid {
+			assert.Nil(t, err, "VLAN tag %s is accepted", value)
+		} else {
+			assert.NotNil(t, err, "VLAN tag %s is rejected", value)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+//Package report renders Equinix Fabric connections, ports and service profiles
+//as tabular reports in CSV, JSON Lines and XLSX formats
+package report
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/equinix/ecx-go/v2"
+)
+
+const (
+	//FormatCSV is a comma separated values format with header row
+	FormatCSV Format = "csv"
+	//FormatJSONL is a JSON Lines format with one JSON object per row
+	FormatJSONL Format = "jsonl"
+	//FormatXLSX is an Office Open XML spreadsheet format with single worksheet
+	FormatXLSX Format = "xlsx"
+)
+
+//Format describes report output format
+type Format string
+
+//Table describes rendered report. Cells hold string, int, bool values
+//or nil when value is not available
+type Table struct {
+	Name    string
+	Columns []string
+	Headers []string
+	Rows    [][]interface{}
+}
+
+//Joins holds related resources used to resolve references in joined report columns,
+//i.e. name of connection's port
+type Joins struct {
+	Ports    []ecx.Port
+	Profiles []ecx.L2ServiceProfile
+}
+
+//ParseFormat returns report format with a given name
+func ParseFormat(name string) (Format, error) {
+	switch f := Format(strings.ToLower(name)); f {
+	case FormatCSV, FormatJSONL, FormatXLSX:
+		return f, nil
+	}
+	return "", fmt.Errorf("unsupported report format %q", name)
+}
+
+//Connections creates report of given layer 2 connections with given columns.
+//When no columns are given, DefaultConnectionColumns are used. Rows are sorted
+//by connection UUID and name, so reports from subsequent runs can be compared
+func Connections(conns []ecx.L2Connection, joins Joins, columns ...string) (*Table, error) {
+	if len(columns) == 0 {
+		columns = DefaultConnectionColumns
+	}
+	ports := make(map[string]ecx.Port, len(joins.Ports))
+	for _, port := range joins.Ports {
+		ports[ecx.StringValue(port.UUID)] = port
+	}
+	profiles := make(map[string]ecx.L2ServiceProfile, len(joins.Profiles))
+	for _, profile := range joins.Profiles {
+		profiles[ecx.StringValue(profile.UUID)] = profile
+	}
+	rows := make([]interface{}, len(conns))
+	for i := range conns {
+		row := connectionRow{conn: conns[i]}
+		if port, ok := ports[ecx.StringValue(conns[i].PortUUID)]; ok {
+			row.port = &port
+		}
+		if profile, ok := profiles[ecx.StringValue(conns[i].ProfileUUID)]; ok {
+			row.profile = &profile
+		}
+		rows[i] = row
+	}
+	sortRows(rows, func(row interface{}) (*string, *string) {
+		conn := row.(connectionRow).conn
+		return conn.UUID, conn.Name
+	})
+	return newTable("connections", connectionColumns, columns, rows)
+}
+
+//Ports creates report of given user ports with given columns.
+//When no columns are given, DefaultPortColumns are used. Rows are sorted
+//by port UUID and name, so reports from subsequent runs can be compared
+func Ports(ports []ecx.Port, columns ...string) (*Table, error) {
+	if len(columns) == 0 {
+		columns = DefaultPortColumns
+	}
+	rows := make([]interface{}, len(ports))
+	for i := range ports {
+		rows[i] = ports[i]
+	}
+	sortRows(rows, func(row interface{}) (*string, *string) {
+		port := row.(ecx.Port)
+		return port.UUID, port.Name
+	})
+	return newTable("ports", portColumns, columns, rows)
+}
+
+//ServiceProfiles creates report of given layer 2 service profiles with given columns.
+//When no columns are given, DefaultServiceProfileColumns are used. Rows are sorted
+//by profile UUID and name, so reports from subsequent runs can be compared
+func ServiceProfiles(profiles []ecx.L2ServiceProfile, columns ...string) (*Table, error) {
+	if len(columns) == 0 {
+		columns = DefaultServiceProfileColumns
+	}
+	rows := make([]interface{}, len(profiles))
+	for i := range profiles {
+		rows[i] = profiles[i]
+	}
+	sortRows(rows, func(row interface{}) (*string, *string) {
+		profile := row.(ecx.L2ServiceProfile)
+		return profile.UUID, profile.Name
+	})
+	return newTable("service profiles", serviceProfileColumns, columns, rows)
+}
+
+//Write writes report to a given writer in a given format
+func (t *Table) Write(w io.Writer, format Format) error {
+	switch format {
+	case FormatCSV:
+		return t.WriteCSV(w)
+	case FormatJSONL:
+		return t.WriteJSONL(w)
+	case FormatXLSX:
+		return t.WriteXLSX(w)
+	}
+	return fmt.Errorf("unsupported report format %q", format)
+}
+
+func newTable(name string, available []column, columns []string, rows []interface{}) (*Table, error) {
+	selected := make([]column, len(columns))
+	for i, colName := range columns {
+		col, ok := findColumn(available, colName)
+		if !ok {
+			return nil, fmt.Errorf("unknown %s report column %q", name, colName)
+		}
+		selected[i] = col
+	}
+	table := &Table{
+		Name:    name,
+		Columns: make([]string, len(selected)),
+		Headers: make([]string, len(selected)),
+		Rows:    make([][]interface{}, len(rows)),
+	}
+	for i, col := range selected {
+		table.Columns[i] = col.name
+		table.Headers[i] = col.header
+	}
+	for i, row := range rows {
+		table.Rows[i] = make([]interface{}, len(selected))
+		for j, col := range selected {
+			table.Rows[i][j] = col.value(row)
+		}
+	}
+	return table, nil
+}
+
+func findColumn(columns []column, name string) (column, bool) {
+	for _, col := range columns {
+		if col.name == name {
+			return col, true
+		}
+	}
+	return column{}, false
+}
+
+func sortRows(rows []interface{}, key func(row interface{}) (*string, *string)) {
+	sort.SliceStable(rows, func(i, j int) bool {
+		iUUID, iName := key(rows[i])
+		jUUID, jName := key(rows[j])
+		if ecx.StringValue(iUUID) != ecx.StringValue(jUUID) {
+			return ecx.StringValue(iUUID) < ecx.StringValue(jUUID)
+		}
+		return ecx.StringValue(iName) < ecx.StringValue(jName)
+	})
+}
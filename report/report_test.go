@@ -0,0 +1,185 @@
+package report
+
+import (
+	"archive/zip"
+	"bytes"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/equinix/ecx-go/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+var testConnections = []ecx.L2Connection{
+	{
+		UUID:                ecx.String("uuid-2"),
+		Name:                ecx.String("conn, second"),
+		Status:              ecx.String(ecx.ConnectionStatusProvisioned),
+		ProviderStatus:      ecx.String(ecx.ConnectionStatusProvisioned),
+		Speed:               ecx.Int(1),
+		SpeedUnit:           ecx.String("GB"),
+		PortUUID:            ecx.String("port-2"),
+		VlanSTag:            ecx.Int(200),
+		SellerMetroCode:     ecx.String("SV"),
+		ProfileUUID:         ecx.String("profile-1"),
+		RedundancyGroup:     ecx.String("group-1"),
+		PurchaseOrderNumber: ecx.String("PO-2"),
+	},
+	{
+		UUID:            ecx.String("uuid-1"),
+		Name:            ecx.String("conn-first"),
+		Status:          ecx.String(ecx.ConnectionStatusProvisioning),
+		Speed:           ecx.Int(50),
+		SpeedUnit:       ecx.String("MB"),
+		PortUUID:        ecx.String("port-1"),
+		VlanSTag:        ecx.Int(100),
+		VlanCTag:        ecx.Int(101),
+		SellerMetroCode: ecx.String("AM"),
+	},
+}
+
+var testPorts = []ecx.Port{
+	{UUID: ecx.String("port-1"), Name: ecx.String("port-one"), MetroCode: ecx.String("AM"), Speed: ecx.Int(10), SpeedUnit: ecx.String("GB"), IsLAG: ecx.Bool(true)},
+	{UUID: ecx.String("port-2"), Name: ecx.String("port-two"), MetroCode: ecx.String("SV")},
+}
+
+func TestConnections_defaultColumns(t *testing.T) {
+	//When
+	table, err := Connections(testConnections, Joins{})
+
+	//Then
+	assert.Nil(t, err, "Report should not return an error")
+	assert.Equal(t, DefaultConnectionColumns, table.Columns, "Columns match")
+	assert.Equal(t, len(DefaultConnectionColumns), len(table.Headers), "Number of headers matches")
+	assert.Equal(t, 2, len(table.Rows), "Number of rows matches")
+	assert.Equal(t, "uuid-1", table.Rows[0][1], "Rows are sorted by UUID")
+	assert.Equal(t, "uuid-2", table.Rows[1][1], "Rows are sorted by UUID")
+}
+
+func TestConnections_joinedColumns(t *testing.T) {
+	//Given
+	joins := Joins{
+		Ports:    testPorts,
+		Profiles: []ecx.L2ServiceProfile{{UUID: ecx.String("profile-1"), Name: ecx.String("profile-one")}},
+	}
+
+	//When
+	table, err := Connections(testConnections, joins, ColumnUUID, ColumnPortName, ColumnPortMetro, ColumnProfileName)
+
+	//Then
+	assert.Nil(t, err, "Report should not return an error")
+	assert.Equal(t, []interface{}{"uuid-1", "port-one", "AM", nil}, table.Rows[0], "First row matches")
+	assert.Equal(t, []interface{}{"uuid-2", "port-two", "SV", "profile-one"}, table.Rows[1], "Second row matches")
+}
+
+func TestConnections_unknownColumn(t *testing.T) {
+	//When
+	table, err := Connections(testConnections, Joins{}, ColumnName, "unknown")
+
+	//Then
+	assert.NotNil(t, err, "Report should return an error")
+	assert.Nil(t, table, "Report should not return a table")
+}
+
+func TestTable_WriteCSV(t *testing.T) {
+	//Given
+	table, err := Connections(testConnections, Joins{Ports: testPorts}, ColumnName, ColumnSpeed, ColumnSpeedUnit, ColumnPortName, ColumnVlanCTag)
+	if err != nil {
+		assert.Failf(t, "Cannot create report due to %s", err.Error())
+	}
+	buf := &bytes.Buffer{}
+
+	//When
+	err = table.Write(buf, FormatCSV)
+
+	//Then
+	assert.Nil(t, err, "Write should not return an error")
+	expected := "Name,Speed,Speed unit,Port name,C-Tag\n" +
+		"conn-first,50,MB,port-one,101\n" +
+		"\"conn, second\",1,GB,port-two,\n"
+	assert.Equal(t, expected, buf.String(), "CSV output matches")
+}
+
+func TestTable_WriteJSONL(t *testing.T) {
+	//Given
+	table, err := Connections(testConnections, Joins{}, ColumnUUID, ColumnVlanSTag, ColumnVlanCTag, ColumnStatus)
+	if err != nil {
+		assert.Failf(t, "Cannot create report due to %s", err.Error())
+	}
+	buf := &bytes.Buffer{}
+
+	//When
+	err = table.Write(buf, FormatJSONL)
+
+	//Then
+	assert.Nil(t, err, "Write should not return an error")
+	expected := `{"uuid":"uuid-1","vlan_s_tag":100,"vlan_c_tag":101,"status":"PROVISIONING"}` + "\n" +
+		`{"uuid":"uuid-2","vlan_s_tag":200,"vlan_c_tag":null,"status":"PROVISIONED"}` + "\n"
+	assert.Equal(t, expected, buf.String(), "JSONL output matches")
+}
+
+func TestTable_WriteXLSX(t *testing.T) {
+	//Given
+	table, err := Ports(testPorts, ColumnName, ColumnSpeed, ColumnLAG)
+	if err != nil {
+		assert.Failf(t, "Cannot create report due to %s", err.Error())
+	}
+	buf := &bytes.Buffer{}
+	again := &bytes.Buffer{}
+
+	//When
+	err = table.Write(buf, FormatXLSX)
+	table.Write(again, FormatXLSX)
+
+	//Then
+	assert.Nil(t, err, "Write should not return an error")
+	assert.Equal(t, buf.Bytes(), again.Bytes(), "Output is stable between runs")
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		assert.Failf(t, "Cannot read spreadsheet due to %s", err.Error())
+	}
+	files := make(map[string]string)
+	for _, f := range zr.File {
+		rc, _ := f.Open()
+		content, _ := ioutil.ReadAll(rc)
+		rc.Close()
+		files[f.Name] = string(content)
+	}
+	assert.Contains(t, files, "[Content_Types].xml", "Content types are present")
+	assert.Contains(t, files["xl/workbook.xml"], `<sheet name="ports"`, "Sheet name matches")
+	sheet := files["xl/worksheets/sheet1.xml"]
+	assert.Contains(t, sheet, `<c r="A1" t="inlineStr"><is><t>Name</t></is></c>`, "Header cell matches")
+	assert.Contains(t, sheet, `<c r="A2" t="inlineStr"><is><t>port-one</t></is></c>`, "String cell matches")
+	assert.Contains(t, sheet, `<c r="B2"><v>10</v></c>`, "Numeric cell matches")
+	assert.Contains(t, sheet, `<c r="C2" t="b"><v>1</v></c>`, "Boolean cell matches")
+	assert.False(t, strings.Contains(sheet, `r="B3"`), "Unavailable value is skipped")
+}
+
+func TestServiceProfiles(t *testing.T) {
+	//Given
+	profiles := []ecx.L2ServiceProfile{
+		{
+			UUID:       ecx.String("profile-1"),
+			Name:       ecx.String("profile-one"),
+			Private:    ecx.Bool(false),
+			SpeedBands: []ecx.L2ServiceProfileSpeedBand{{Speed: ecx.Int(50), SpeedUnit: ecx.String("MB")}, {Speed: ecx.Int(1), SpeedUnit: ecx.String("GB")}},
+			Metros:     []ecx.L2SellerProfileMetro{{Code: ecx.String("AM")}, {Code: ecx.String("SV")}},
+		},
+	}
+
+	//When
+	table, err := ServiceProfiles(profiles, ColumnName, ColumnPrivate, ColumnSpeedBands, ColumnMetros, ColumnPorts)
+
+	//Then
+	assert.Nil(t, err, "Report should not return an error")
+	assert.Equal(t, []interface{}{"profile-one", false, "50MB;1GB", "AM;SV", nil}, table.Rows[0], "Row matches")
+}
+
+func TestParseFormat(t *testing.T) {
+	format, err := ParseFormat("XLSX")
+	assert.Nil(t, err, "ParseFormat should not return an error")
+	assert.Equal(t, FormatXLSX, format, "Format matches")
+	_, err = ParseFormat("pdf")
+	assert.NotNil(t, err, "ParseFormat should return an error for unsupported format")
+}
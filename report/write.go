@@ -0,0 +1,183 @@
+package report
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+//xlsxModified is a modification time of XLSX archive entries. Fixed time keeps
+//spreadsheets of the same report byte-for-byte identical
+var xlsxModified = time.Date(1980, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+//WriteCSV writes report in CSV format. First record holds column headers,
+//unavailable values are written as empty fields
+func (t *Table) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(t.Headers); err != nil {
+		return err
+	}
+	record := make([]string, len(t.Columns))
+	for _, row := range t.Rows {
+		for i, cell := range row {
+			record[i] = formatCell(cell)
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+//WriteJSONL writes report in JSON Lines format. Each row is written as JSON object
+//with column names as keys, in report's column order
+func (t *Table) WriteJSONL(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	for _, row := range t.Rows {
+		bw.WriteByte('{')
+		for i, cell := range row {
+			if i > 0 {
+				bw.WriteByte(',')
+			}
+			key, err := json.Marshal(t.Columns[i])
+			if err != nil {
+				return err
+			}
+			value, err := json.Marshal(cell)
+			if err != nil {
+				return err
+			}
+			bw.Write(key)
+			bw.WriteByte(':')
+			bw.Write(value)
+		}
+		bw.WriteString("}\n")
+	}
+	return bw.Flush()
+}
+
+//WriteXLSX writes report as XLSX spreadsheet with single worksheet.
+//First row holds column headers, numeric and boolean values are written as typed cells
+func (t *Table) WriteXLSX(w io.Writer) error {
+	zw := zip.NewWriter(w)
+	sheetName := t.Name
+	if sheetName == "" {
+		sheetName = "report"
+	}
+	files := []struct {
+		name    string
+		content []byte
+	}{
+		{"[Content_Types].xml", []byte(xlsxContentTypes)},
+		{"_rels/.rels", []byte(xlsxRels)},
+		{"xl/workbook.xml", []byte(fmt.Sprintf(xlsxWorkbook, xmlEscape(sheetName)))},
+		{"xl/_rels/workbook.xml.rels", []byte(xlsxWorkbookRels)},
+		{"xl/worksheets/sheet1.xml", t.xlsxSheet()},
+	}
+	for _, file := range files {
+		fw, err := zw.CreateHeader(&zip.FileHeader{
+			Name:     file.name,
+			Method:   zip.Deflate,
+			Modified: xlsxModified,
+		})
+		if err != nil {
+			return err
+		}
+		if _, err := fw.Write(file.content); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+func (t *Table) xlsxSheet() []byte {
+	buf := &bytes.Buffer{}
+	buf.WriteString(xml.Header)
+	buf.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	headers := make([]interface{}, len(t.Headers))
+	for i := range t.Headers {
+		headers[i] = t.Headers[i]
+	}
+	writeXLSXRow(buf, 1, headers)
+	for i, row := range t.Rows {
+		writeXLSXRow(buf, i+2, row)
+	}
+	buf.WriteString(`</sheetData></worksheet>`)
+	return buf.Bytes()
+}
+
+func writeXLSXRow(buf *bytes.Buffer, rowNum int, cells []interface{}) {
+	fmt.Fprintf(buf, `<row r="%d">`, rowNum)
+	for i, cell := range cells {
+		ref := xlsxColumnName(i) + strconv.Itoa(rowNum)
+		switch v := cell.(type) {
+		case nil:
+			continue
+		case int:
+			fmt.Fprintf(buf, `<c r="%s"><v>%d</v></c>`, ref, v)
+		case bool:
+			value := 0
+			if v {
+				value = 1
+			}
+			fmt.Fprintf(buf, `<c r="%s" t="b"><v>%d</v></c>`, ref, value)
+		default:
+			fmt.Fprintf(buf, `<c r="%s" t="inlineStr"><is><t>%s</t></is></c>`, ref, xmlEscape(formatCell(v)))
+		}
+	}
+	buf.WriteString(`</row>`)
+}
+
+//xlsxColumnName returns spreadsheet column name for a given zero based index, i.e. A, Z, AA
+func xlsxColumnName(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+	return name
+}
+
+func formatCell(cell interface{}) string {
+	if cell == nil {
+		return ""
+	}
+	return fmt.Sprint(cell)
+}
+
+func xmlEscape(s string) string {
+	buf := &bytes.Buffer{}
+	xml.EscapeText(buf, []byte(s))
+	return buf.String()
+}
+
+const xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+	`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+	`<Default Extension="xml" ContentType="application/xml"/>` +
+	`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+	`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+	`</Types>`
+
+const xlsxRels = `<?xml version="1.0" encoding="UTF-8"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+	`</Relationships>`
+
+const xlsxWorkbook = `<?xml version="1.0" encoding="UTF-8"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+	`<sheets><sheet name="%s" sheetId="1" r:id="rId1"/></sheets>` +
+	`</workbook>`
+
+const xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+	`</Relationships>`
@@ -2,23 +2,157 @@ package ecx
 
 import (
 	"context"
+	"log"
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/equinix/rest-go"
+	"github.com/go-resty/resty/v2"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 //RestClient describes Equinix Fabric client that uses REST API
 type RestClient struct {
 	*rest.Client
+	ctx             context.Context
+	pageConcurrency int
+	metrics         Metrics
+	tracer          trace.Tracer
+	interceptors    []Interceptor
+	//requestContexts holds contexts of invoked requests, keyed by request.
+	//Underlying REST client runs each request in client's context, so request's
+	//context is applied again right before request is sent
+	requestContexts *sync.Map
 }
 
-//NewClient creates new Equinix Fabric REST API client with a given baseURL and http.Client
-func NewClient(ctx context.Context, baseURL string, httpClient *http.Client) *RestClient {
+//apiPath is Equinix Fabric API path built from a template with named parameters,
+//i.e. /ecx/v3/l2/connections/{connectionUUID}. Unlike the path, template does not
+//carry resource identifiers
+type apiPath struct {
+	template string
+	values   []string
+}
+
+//NewClient creates new Equinix Fabric REST API client with a given baseURL, http.Client
+//and options. Given http.Client is used as is, i.e. it has to authorize requests, so
+//options that configure HTTP client are not applied and are reported with standard logger
+func NewClient(ctx context.Context, baseURL string, httpClient *http.Client, options ...ClientOption) *RestClient {
+	o := newClientOptions(options)
+	if ignored := o.httpClientOptions(); len(ignored) > 0 {
+		log.Printf("[WARN] ecx: NewClient does not apply %s options, use NewClientWithOptions to configure HTTP client",
+			strings.Join(ignored, ", "))
+	}
+	return newClient(ctx, baseURL, httpClient, o)
+}
+
+func newClient(ctx context.Context, baseURL string, httpClient *http.Client, o clientOptions) *RestClient {
 	rest := rest.NewClient(ctx, baseURL, httpClient)
-	rest.SetHeader("User-agent", "equinix/ecx-go")
-	return &RestClient{rest}
+	rest.SetHeader("User-agent", userAgent)
+	c := &RestClient{Client: rest, ctx: ctx, pageConcurrency: defaultPageConcurrency, requestContexts: &sync.Map{}}
+	rest.OnBeforeRequest(func(_ *resty.Client, req *resty.Request) error {
+		if reqCtx, ok := c.requestContexts.Load(req); ok {
+			req.SetContext(reqCtx.(context.Context))
+		}
+		return nil
+	})
+	c.applyOptions(o)
+	return c
+}
+
+//execute runs request of a given client operation on a given path
+func (c RestClient) execute(operation string, req *resty.Request, method string, path apiPath) error {
+	return c.invoke(operation, operation, c.requestSpanAttributes(method, path), req, method, path)
+}
+
+//invoke runs request of a given client operation through client's interceptors.
+//Request is traced with a span of a given name and reported to metrics receiver
+func (c RestClient) invoke(spanName string, operation string, attributes []attribute.KeyValue, req *resty.Request, method string, path apiPath) error {
+	if len(c.interceptors) == 0 && c.metrics == nil && c.tracer == nil {
+		return c.Execute(req, method, path.String())
+	}
+	c, span := c.startSpan(spanName, trace.SpanKindClient, attributes...)
+	inv := &Invocation{
+		Context:      c.ctx,
+		Operation:    operation,
+		Method:       method,
+		Path:         path.String(),
+		PathTemplate: path.template,
+		Query:        req.QueryParam,
+		Header:       req.Header,
+		Body:         req.Body,
+		Result:       req.Result,
+	}
+	err := c.chain(func(inv *Invocation) error {
+		return c.do(inv, req)
+	})(inv)
+	endRequestSpan(span, inv.StatusCode, err)
+	return err
+}
+
+//do runs request described by a given invocation in invocation's context
+func (c RestClient) do(inv *Invocation, req *resty.Request) error {
+	ctx := inv.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	req.Header = inv.Header
+	req.QueryParam = inv.Query
+	req.Body = inv.Body
+	req.Result = inv.Result
+	req.SetContext(ctx)
+	c.injectTraceContext(ctx, req)
+	c.requestContexts.Store(req, ctx)
+	defer c.requestContexts.Delete(req)
+	start := time.Now()
+	resp, err := c.Do(inv.Method, inv.Path, req)
+	inv.StatusCode = 0
+	if resp != nil && resp.RawResponse != nil {
+		inv.StatusCode = resp.StatusCode()
+	}
+	if c.metrics != nil {
+		c.metrics.ObserveRequest(inv.Operation, inv.StatusCode, time.Since(start), err)
+	}
+	return err
+}
+
+//newAPIPath creates API path from a given template and values of its parameters, in order
+func newAPIPath(template string, values ...string) apiPath {
+	return apiPath{template: template, values: values}
+}
+
+//String returns path with template parameters replaced by escaped values
+func (p apiPath) String() string {
+	var sb strings.Builder
+	p.expand(func(name string, value string) {
+		sb.WriteString(url.PathEscape(value))
+	}, func(literal string) {
+		sb.WriteString(literal)
+	})
+	return sb.String()
+}
+
+//params calls a given function with name and value of each template parameter
+func (p apiPath) params(param func(name string, value string)) {
+	p.expand(param, func(string) {})
+}
+
+func (p apiPath) expand(param func(name string, value string), literal func(string)) {
+	rest := p.template
+	for _, value := range p.values {
+		start := strings.Index(rest, "{")
+		end := strings.Index(rest, "}")
+		if start < 0 || end < start {
+			break
+		}
+		literal(rest[:start])
+		param(rest[start+1:end], value)
+		rest = rest[end+1:]
+	}
+	literal(rest)
 }
 
 func buildQueryParamValueString(values []string) string {
@@ -31,3 +165,12 @@ func buildQueryParamValueString(values []string) string {
 	}
 	return sb.String()
 }
+
+func isStringInSlice(value string, values []string) bool {
+	for i := range values {
+		if values[i] == value {
+			return true
+		}
+	}
+	return false
+}
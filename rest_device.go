@@ -0,0 +1,162 @@
+package ecx
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/equinix/ecx-go/v2/internal/api"
+	"github.com/equinix/rest-go"
+)
+
+const (
+	//DeviceInterfaceStatusAvailable indicates that device interface is not used by any connection
+	DeviceInterfaceStatusAvailable = "AVAILABLE"
+	//DeviceInterfaceStatusAssigned indicates that device interface is used by a connection
+	DeviceInterfaceStatusAssigned = "ASSIGNED"
+)
+
+//GetDevices retrieves list of Network Edge virtual devices of a customer account
+//associated with authenticated application. When statuses are given, only devices
+//in one of given statuses are returned
+func (c RestClient) GetDevices(statuses []string) ([]Device, error) {
+	path := newAPIPath("/ne/v1/devices")
+	pagingConfig := rest.DefaultPagingConfig().
+		SetSizeParamName("size").
+		SetPageParamName("pageNumber").
+		SetContentFieldName("Data").
+		SetFirstPageNumber(1)
+	if len(statuses) > 0 {
+		pagingConfig.SetAdditionalParams(map[string]string{"status": buildQueryParamValueString(statuses)})
+	}
+	content, err := c.getPaginated("GetDevices", path, &api.DevicesResponse{}, pagingConfig, func(item interface{}) string {
+		return StringValue(item.(api.Device).UUID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	transformed := make([]Device, len(content))
+	for i := range content {
+		transformed[i] = *mapDeviceAPIToDomain(content[i].(api.Device))
+	}
+	return transformed, nil
+}
+
+//GetDevice retrieves Network Edge virtual device with a given UUID
+func (c RestClient) GetDevice(uuid string) (*Device, error) {
+	path := newAPIPath("/ne/v1/devices/{deviceUUID}", uuid)
+	respBody := api.Device{}
+	req := c.R().SetResult(&respBody)
+	if err := c.execute("GetDevice", req, http.MethodGet, path); err != nil {
+		return nil, err
+	}
+	return mapDeviceAPIToDomain(respBody), nil
+}
+
+//GetDeviceFreeInterfaces retrieves interfaces of Network Edge virtual device with a given UUID
+//that are available for new connections
+func (c RestClient) GetDeviceFreeInterfaces(uuid string) ([]DeviceInterface, error) {
+	device, err := c.GetDevice(uuid)
+	if err != nil {
+		return nil, err
+	}
+	var free []DeviceInterface
+	for _, iface := range device.Interfaces {
+		if StringValue(iface.Status) == DeviceInterfaceStatusAvailable && StringValue(iface.AssignedType) == "" {
+			free = append(free, iface)
+		}
+	}
+	return free, nil
+}
+
+//CreateDeviceL2Connection operation creates non-redundant layer 2 connection originating
+//from Network Edge virtual device. Connection structure needs to have DeviceUUID defined.
+//Upon successful creation, UUID of a connection is returned
+func (c RestClient) CreateDeviceL2Connection(conn L2Connection) (*string, error) {
+	if StringValue(conn.DeviceUUID) == "" {
+		return nil, fmt.Errorf("device connection needs to have DeviceUUID defined")
+	}
+	path := newAPIPath("/ne/v1/l2/connections")
+	primaryUUID, _, err := c.postL2Connection("CreateDeviceL2Connection", path, createL2ConnectionRequest(conn))
+	return primaryUUID, err
+}
+
+//CreateDeviceL2RedundantConnection operation creates redundant layer 2 connection originating
+//from Network Edge virtual devices. Primary connection structure needs to have DeviceUUID defined.
+//When secondary connection structure has neither DeviceUUID, PortUUID nor ServiceToken,
+//secondary connection originates from the primary device. Upon successful creation, UUIDs of primary and secondary connections are returned
+func (c RestClient) CreateDeviceL2RedundantConnection(primary L2Connection, secondary L2Connection) (*string, *string, error) {
+	if StringValue(primary.DeviceUUID) == "" {
+		return nil, nil, fmt.Errorf("primary device connection needs to have DeviceUUID defined")
+	}
+	if StringValue(secondary.DeviceUUID) == "" && StringValue(secondary.PortUUID) == "" && StringValue(secondary.ServiceToken) == "" {
+		secondary.DeviceUUID = primary.DeviceUUID
+	}
+	path := newAPIPath("/ne/v1/l2/connections")
+	return c.postL2Connection("CreateDeviceL2RedundantConnection", path, createL2RedundantConnectionRequest(primary, secondary))
+}
+
+//CreateDeviceToDeviceL2Connection operation creates layer 2 connection between two Network Edge
+//virtual devices. Connection structure needs to have DeviceUUID and ZSideDeviceUUID defined.
+//Upon successful creation, UUID of a connection is returned
+func (c RestClient) CreateDeviceToDeviceL2Connection(conn L2Connection) (*string, error) {
+	if StringValue(conn.DeviceUUID) == "" || StringValue(conn.ZSideDeviceUUID) == "" {
+		return nil, fmt.Errorf("device to device connection needs to have DeviceUUID and ZSideDeviceUUID defined")
+	}
+	path := newAPIPath("/ne/v1/l2/connections")
+	primaryUUID, _, err := c.postL2Connection("CreateDeviceToDeviceL2Connection", path, createL2ConnectionRequest(conn))
+	return primaryUUID, err
+}
+
+//GetDeviceL2Connection operation retrieves layer 2 connection with a given UUID
+//that originates from Network Edge virtual device
+func (c RestClient) GetDeviceL2Connection(uuid string) (*L2Connection, error) {
+	conn, err := c.GetL2Connection(uuid)
+	if err != nil {
+		return nil, err
+	}
+	if StringValue(conn.DeviceUUID) == "" {
+		return nil, fmt.Errorf("connection %q does not originate from a virtual device", uuid)
+	}
+	return conn, nil
+}
+
+//DeleteDeviceL2Connection deletes layer 2 connection with a given UUID
+//that originates from Network Edge virtual device
+func (c RestClient) DeleteDeviceL2Connection(uuid string) error {
+	if _, err := c.GetDeviceL2Connection(uuid); err != nil {
+		return err
+	}
+	return c.DeleteL2Connection(uuid)
+}
+
+func mapDeviceAPIToDomain(apiDevice api.Device) *Device {
+	return &Device{
+		UUID:           apiDevice.UUID,
+		Name:           apiDevice.Name,
+		DeviceTypeCode: apiDevice.DeviceTypeCode,
+		Status:         apiDevice.Status,
+		MetroCode:      apiDevice.MetroCode,
+		IBX:            apiDevice.IBX,
+		Region:         apiDevice.Region,
+		RedundancyType: apiDevice.RedundancyType,
+		RedundantUUID:  apiDevice.RedundantUUID,
+		Interfaces:     mapDeviceInterfacesAPIToDomain(apiDevice.Interfaces),
+	}
+}
+
+func mapDeviceInterfacesAPIToDomain(apiInterfaces []api.DeviceInterface) []DeviceInterface {
+	transformed := make([]DeviceInterface, len(apiInterfaces))
+	for i := range apiInterfaces {
+		transformed[i] = DeviceInterface{
+			ID:                apiInterfaces[i].ID,
+			Name:              apiInterfaces[i].Name,
+			Status:            apiInterfaces[i].Status,
+			OperationalStatus: apiInterfaces[i].OperationalStatus,
+			MACAddress:        apiInterfaces[i].MACAddress,
+			IPAddress:         apiInterfaces[i].IPAddress,
+			AssignedType:      apiInterfaces[i].AssignedType,
+			Type:              apiInterfaces[i].Type,
+		}
+	}
+	return transformed
+}
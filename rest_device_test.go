@@ -0,0 +1,373 @@
+package ecx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/equinix/ecx-go/v2/internal/api"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetDevices(t *testing.T) {
+	//Given
+	respBody := api.DevicesResponse{}
+	if err := readJSONData("./test-fixtures/ne_devices_get_resp.json", &respBody); err != nil {
+		assert.Failf(t, "Cannot read test response due to %s", err.Error())
+	}
+	statuses := []string{"PROVISIONED", "PROVISIONING"}
+	pageSize := IntValue(respBody.PageSize)
+	expectedQuery := url.Values{}
+	expectedQuery.Set("size", fmt.Sprintf("%d", pageSize))
+	expectedQuery.Set("status", buildQueryParamValueString(statuses))
+	testHc := &http.Client{}
+	httpmock.ActivateNonDefault(testHc)
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ne/v1/devices?%s", baseURL, expectedQuery.Encode()),
+		func(r *http.Request) (*http.Response, error) {
+			resp, _ := httpmock.NewJsonResponse(200, respBody)
+			return resp, nil
+		},
+	)
+	defer httpmock.DeactivateAndReset()
+
+	//When
+	ecxClient := NewClient(context.Background(), baseURL, testHc)
+	ecxClient.SetPageSize(pageSize)
+	devices, err := ecxClient.GetDevices(statuses)
+
+	//Then
+	assert.Nil(t, err, "Client should not return an error")
+	assert.NotNil(t, devices, "Client should return a response")
+	assert.Equal(t, len(respBody.Data), len(devices), "Number of devices matches")
+	for i := range respBody.Data {
+		verifyDevice(t, devices[i], respBody.Data[i])
+	}
+}
+
+func TestGetDevice(t *testing.T) {
+	//Given
+	respBody := api.Device{}
+	if err := readJSONData("./test-fixtures/ne_device_get_resp.json", &respBody); err != nil {
+		assert.Failf(t, "Cannot read test response due to %s", err.Error())
+	}
+	deviceID := StringValue(respBody.UUID)
+	testHc := &http.Client{}
+	httpmock.ActivateNonDefault(testHc)
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ne/v1/devices/%s", baseURL, deviceID),
+		func(r *http.Request) (*http.Response, error) {
+			resp, _ := httpmock.NewJsonResponse(200, respBody)
+			return resp, nil
+		},
+	)
+	defer httpmock.DeactivateAndReset()
+
+	//When
+	ecxClient := NewClient(context.Background(), baseURL, testHc)
+	device, err := ecxClient.GetDevice(deviceID)
+
+	//Then
+	assert.Nil(t, err, "Client should not return an error")
+	assert.NotNil(t, device, "Client should return a response")
+	verifyDevice(t, *device, respBody)
+}
+
+func TestGetDeviceFreeInterfaces(t *testing.T) {
+	//Given
+	respBody := api.Device{}
+	if err := readJSONData("./test-fixtures/ne_device_get_resp.json", &respBody); err != nil {
+		assert.Failf(t, "Cannot read test response due to %s", err.Error())
+	}
+	deviceID := StringValue(respBody.UUID)
+	testHc := &http.Client{}
+	httpmock.ActivateNonDefault(testHc)
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ne/v1/devices/%s", baseURL, deviceID),
+		func(r *http.Request) (*http.Response, error) {
+			resp, _ := httpmock.NewJsonResponse(200, respBody)
+			return resp, nil
+		},
+	)
+	defer httpmock.DeactivateAndReset()
+
+	//When
+	ecxClient := NewClient(context.Background(), baseURL, testHc)
+	interfaces, err := ecxClient.GetDeviceFreeInterfaces(deviceID)
+
+	//Then
+	assert.Nil(t, err, "Client should not return an error")
+	assert.Equal(t, 2, len(interfaces), "Number of free interfaces matches")
+	for _, iface := range interfaces {
+		assert.Equal(t, DeviceInterfaceStatusAvailable, StringValue(iface.Status), "Interface status matches")
+		assert.Nil(t, iface.AssignedType, "Interface is not assigned")
+	}
+	assert.Equal(t, 2, IntValue(interfaces[0].ID), "First free interface ID matches")
+	assert.Equal(t, 3, IntValue(interfaces[1].ID), "Second free interface ID matches")
+}
+
+func TestCreateDeviceL2Connection_explicit(t *testing.T) {
+	//Given
+	respBody := api.CreateL2ConnectionResponse{}
+	if err := readJSONData("./test-fixtures/ecx_l2connection_post_resp.json", &respBody); err != nil {
+		assert.Failf(t, "Cannot read test response due to %s", err.Error())
+	}
+	reqBody := api.L2ConnectionRequest{}
+	testHc := &http.Client{}
+	httpmock.ActivateNonDefault(testHc)
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/ne/v1/l2/connections", baseURL),
+		func(r *http.Request) (*http.Response, error) {
+			if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+				return httpmock.NewStringResponse(400, ""), nil
+			}
+			resp, _ := httpmock.NewJsonResponse(200, respBody)
+			return resp, nil
+		},
+	)
+	defer httpmock.DeactivateAndReset()
+	newConnection := testPrimaryConnection
+	newConnection.PortUUID = nil
+	newConnection.DeviceUUID = String("deviceUUID")
+	newConnection.DeviceInterfaceID = Int(5)
+
+	//When
+	ecxClient := NewClient(context.Background(), baseURL, testHc)
+	uuid, err := ecxClient.CreateDeviceL2Connection(newConnection)
+
+	//Then
+	assert.Nil(t, err, "Client should not return an error")
+	assert.Equal(t, respBody.PrimaryConnectionID, uuid, "UUID matches")
+	verifyL2ConnectionRequest(t, newConnection, reqBody)
+}
+
+func TestCreateDeviceL2Connection_noDevice(t *testing.T) {
+	//Given
+	newConnection := testPrimaryConnection
+
+	//When
+	ecxClient := NewClient(context.Background(), baseURL, &http.Client{})
+	uuid, err := ecxClient.CreateDeviceL2Connection(newConnection)
+
+	//Then
+	assert.NotNil(t, err, "Client should return an error")
+	assert.Nil(t, uuid, "Client should not return UUID")
+}
+
+func TestCreateDeviceL2RedundantConnection(t *testing.T) {
+	//Given
+	respBody := api.CreateL2ConnectionResponse{}
+	if err := readJSONData("./test-fixtures/ecx_l2connection_post_resp.json", &respBody); err != nil {
+		assert.Failf(t, "Cannot read test response due to %s", err.Error())
+	}
+	reqBody := api.L2ConnectionRequest{}
+	testHc := &http.Client{}
+	httpmock.ActivateNonDefault(testHc)
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/ne/v1/l2/connections", baseURL),
+		func(r *http.Request) (*http.Response, error) {
+			if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+				return httpmock.NewStringResponse(400, ""), nil
+			}
+			resp, _ := httpmock.NewJsonResponse(200, respBody)
+			return resp, nil
+		},
+	)
+	defer httpmock.DeactivateAndReset()
+	primary := testPrimaryConnection
+	primary.PortUUID = nil
+	primary.DeviceUUID = String("deviceUUID")
+	primary.DeviceInterfaceID = Int(4)
+	secondary := L2Connection{
+		Name:              String("secondary"),
+		DeviceInterfaceID: Int(5),
+	}
+
+	//When
+	ecxClient := NewClient(context.Background(), baseURL, testHc)
+	priUUID, secUUID, err := ecxClient.CreateDeviceL2RedundantConnection(primary, secondary)
+
+	//Then
+	assert.Nil(t, err, "Client should not return an error")
+	assert.Equal(t, respBody.PrimaryConnectionID, priUUID, "Primary UUID matches")
+	assert.Equal(t, respBody.SecondaryConnectionID, secUUID, "Secondary UUID matches")
+	verifyL2ConnectionRequest(t, primary, reqBody)
+	assert.Equal(t, primary.DeviceUUID, reqBody.SecondaryVirtualDeviceUUID, "Secondary device defaults to primary device")
+	assert.Equal(t, secondary.DeviceInterfaceID, reqBody.SecondaryInterfaceID, "Secondary interface matches")
+	assert.Equal(t, secondary.Name, reqBody.SecondaryName, "Secondary name matches")
+}
+
+func TestCreateDeviceToDeviceL2Connection(t *testing.T) {
+	//Given
+	respBody := api.CreateL2ConnectionResponse{}
+	if err := readJSONData("./test-fixtures/ecx_l2connection_post_resp.json", &respBody); err != nil {
+		assert.Failf(t, "Cannot read test response due to %s", err.Error())
+	}
+	reqBody := api.L2ConnectionRequest{}
+	testHc := &http.Client{}
+	httpmock.ActivateNonDefault(testHc)
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/ne/v1/l2/connections", baseURL),
+		func(r *http.Request) (*http.Response, error) {
+			if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+				return httpmock.NewStringResponse(400, ""), nil
+			}
+			resp, _ := httpmock.NewJsonResponse(200, respBody)
+			return resp, nil
+		},
+	)
+	defer httpmock.DeactivateAndReset()
+	newConnection := L2Connection{
+		Name:              String("device-to-device"),
+		Speed:             Int(50),
+		SpeedUnit:         String("MB"),
+		Notifications:     []string{"janek@equinix.com"},
+		DeviceUUID:        String("deviceUUID"),
+		DeviceInterfaceID: Int(5),
+		ZSideDeviceUUID:   String("zSideDeviceUUID"),
+		ZSideInterfaceID:  Int(6),
+	}
+
+	//When
+	ecxClient := NewClient(context.Background(), baseURL, testHc)
+	uuid, err := ecxClient.CreateDeviceToDeviceL2Connection(newConnection)
+
+	//Then
+	assert.Nil(t, err, "Client should not return an error")
+	assert.Equal(t, respBody.PrimaryConnectionID, uuid, "UUID matches")
+	verifyL2ConnectionRequest(t, newConnection, reqBody)
+}
+
+func TestCreateDeviceToDeviceL2Connection_noZSideDevice(t *testing.T) {
+	//Given
+	newConnection := L2Connection{
+		Name:       String("device-to-device"),
+		DeviceUUID: String("deviceUUID"),
+	}
+
+	//When
+	ecxClient := NewClient(context.Background(), baseURL, &http.Client{})
+	uuid, err := ecxClient.CreateDeviceToDeviceL2Connection(newConnection)
+
+	//Then
+	assert.NotNil(t, err, "Client should return an error")
+	assert.Nil(t, uuid, "Client should not return UUID")
+}
+
+func TestGetDeviceL2Connection_deviceToDevice(t *testing.T) {
+	//Given
+	respBody := api.L2ConnectionResponse{}
+	if err := readJSONData("./test-fixtures/ecx_l2connection_get_resp.json", &respBody); err != nil {
+		assert.Failf(t, "Cannot read test response due to %s", err.Error())
+	}
+	respBody.ZSidePortUUID = nil
+	respBody.ZSideVlanSTag = nil
+	respBody.ZSideVlanCTag = nil
+	respBody.ZSideDeviceUUID = String("zSideDeviceUUID")
+	respBody.ZSideInterfaceID = Int(6)
+	connID := StringValue(respBody.UUID)
+	testHc := &http.Client{}
+	httpmock.ActivateNonDefault(testHc)
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ecx/v3/l2/connections/%s", baseURL, connID),
+		func(r *http.Request) (*http.Response, error) {
+			resp, _ := httpmock.NewJsonResponse(200, respBody)
+			return resp, nil
+		},
+	)
+	defer httpmock.DeactivateAndReset()
+
+	//When
+	ecxClient := NewClient(context.Background(), baseURL, testHc)
+	conn, err := ecxClient.GetDeviceL2Connection(connID)
+
+	//Then
+	assert.Nil(t, err, "Client should not return an error")
+	assert.NotNil(t, conn, "Client should return a response")
+	verifyL2Connection(t, *conn, respBody)
+	assert.Equal(t, "zSideDeviceUUID", StringValue(conn.ZSideDeviceUUID), "ZSideDeviceUUID matches")
+	assert.Equal(t, 6, IntValue(conn.ZSideInterfaceID), "ZSideInterfaceID matches")
+}
+
+func TestDeleteDeviceL2Connection(t *testing.T) {
+	//Given
+	getRespBody := api.L2ConnectionResponse{}
+	if err := readJSONData("./test-fixtures/ecx_l2connection_get_resp.json", &getRespBody); err != nil {
+		assert.Failf(t, "Cannot read test response due to %s", err.Error())
+	}
+	deleteRespBody := api.DeleteL2ConnectionResponse{}
+	if err := readJSONData("./test-fixtures/ecx_l2connection_delete_resp.json", &deleteRespBody); err != nil {
+		assert.Failf(t, "Cannot read test response due to %s", err.Error())
+	}
+	connID := StringValue(getRespBody.UUID)
+	testHc := &http.Client{}
+	httpmock.ActivateNonDefault(testHc)
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ecx/v3/l2/connections/%s", baseURL, connID),
+		func(r *http.Request) (*http.Response, error) {
+			resp, _ := httpmock.NewJsonResponse(200, getRespBody)
+			return resp, nil
+		},
+	)
+	httpmock.RegisterResponder("DELETE", fmt.Sprintf("%s/ecx/v3/l2/connections/%s", baseURL, connID),
+		func(r *http.Request) (*http.Response, error) {
+			resp, _ := httpmock.NewJsonResponse(200, deleteRespBody)
+			return resp, nil
+		},
+	)
+	defer httpmock.DeactivateAndReset()
+
+	//When
+	ecxClient := NewClient(context.Background(), baseURL, testHc)
+	err := ecxClient.DeleteDeviceL2Connection(connID)
+
+	//Then
+	assert.Nil(t, err, "Client should not return an error")
+	assert.Equal(t, 1, httpmock.GetCallCountInfo()[fmt.Sprintf("DELETE %s/ecx/v3/l2/connections/%s", baseURL, connID)], "Connection was deleted")
+}
+
+func TestDeleteDeviceL2Connection_notDeviceConnection(t *testing.T) {
+	//Given
+	getRespBody := api.L2ConnectionResponse{}
+	if err := readJSONData("./test-fixtures/ecx_l2connection_get_resp.json", &getRespBody); err != nil {
+		assert.Failf(t, "Cannot read test response due to %s", err.Error())
+	}
+	getRespBody.VirtualDeviceUUID = nil
+	connID := StringValue(getRespBody.UUID)
+	testHc := &http.Client{}
+	httpmock.ActivateNonDefault(testHc)
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ecx/v3/l2/connections/%s", baseURL, connID),
+		func(r *http.Request) (*http.Response, error) {
+			resp, _ := httpmock.NewJsonResponse(200, getRespBody)
+			return resp, nil
+		},
+	)
+	defer httpmock.DeactivateAndReset()
+
+	//When
+	ecxClient := NewClient(context.Background(), baseURL, testHc)
+	err := ecxClient.DeleteDeviceL2Connection(connID)
+
+	//Then
+	assert.NotNil(t, err, "Client should return an error")
+	assert.Equal(t, 0, httpmock.GetCallCountInfo()[fmt.Sprintf("DELETE %s/ecx/v3/l2/connections/%s", baseURL, connID)], "Connection was not deleted")
+}
+
+func verifyDevice(t *testing.T, device Device, apiDevice api.Device) {
+	assert.Equal(t, apiDevice.UUID, device.UUID, "UUID matches")
+	assert.Equal(t, apiDevice.Name, device.Name, "Name matches")
+	assert.Equal(t, apiDevice.DeviceTypeCode, device.DeviceTypeCode, "DeviceTypeCode matches")
+	assert.Equal(t, apiDevice.Status, device.Status, "Status matches")
+	assert.Equal(t, apiDevice.MetroCode, device.MetroCode, "MetroCode matches")
+	assert.Equal(t, apiDevice.IBX, device.IBX, "IBX matches")
+	assert.Equal(t, apiDevice.Region, device.Region, "Region matches")
+	assert.Equal(t, apiDevice.RedundancyType, device.RedundancyType, "RedundancyType matches")
+	assert.Equal(t, apiDevice.RedundantUUID, device.RedundantUUID, "RedundantUUID matches")
+	assert.Equal(t, len(apiDevice.Interfaces), len(device.Interfaces), "Number of interfaces matches")
+	for i := range apiDevice.Interfaces {
+		assert.Equal(t, apiDevice.Interfaces[i].ID, device.Interfaces[i].ID, "Interface ID matches")
+		assert.Equal(t, apiDevice.Interfaces[i].Name, device.Interfaces[i].Name, "Interface Name matches")
+		assert.Equal(t, apiDevice.Interfaces[i].Status, device.Interfaces[i].Status, "Interface Status matches")
+		assert.Equal(t, apiDevice.Interfaces[i].OperationalStatus, device.Interfaces[i].OperationalStatus, "Interface OperationalStatus matches")
+		assert.Equal(t, apiDevice.Interfaces[i].MACAddress, device.Interfaces[i].MACAddress, "Interface MACAddress matches")
+		assert.Equal(t, apiDevice.Interfaces[i].IPAddress, device.Interfaces[i].IPAddress, "Interface IPAddress matches")
+		assert.Equal(t, apiDevice.Interfaces[i].AssignedType, device.Interfaces[i].AssignedType, "Interface AssignedType matches")
+		assert.Equal(t, apiDevice.Interfaces[i].Type, device.Interfaces[i].Type, "Interface Type matches")
+	}
+}
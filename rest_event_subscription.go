@@ -0,0 +1,69 @@
+package ecx
+
+import (
+	"net/http"
+
+	"github.com/equinix/ecx-go/v2/internal/api"
+	"github.com/equinix/rest-go"
+)
+
+//GetEventSubscriptions operation retrieves event notification subscriptions
+//of a customer account associated with authenticated application
+func (c RestClient) GetEventSubscriptions() ([]EventSubscription, error) {
+	path := newAPIPath("/ecx/v3/events/subscriptions")
+	content, err := c.getPaginated("GetEventSubscriptions", path, &api.EventSubscriptionsResponse{}, rest.DefaultPagingConfig().
+		SetSizeParamName("pageSize").
+		SetPageParamName("pageNumber").
+		SetFirstPageNumber(0), func(item interface{}) string {
+		return StringValue(item.(api.EventSubscription).UUID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	transformed := make([]EventSubscription, len(content))
+	for i := range content {
+		transformed[i] = mapEventSubscriptionAPIToDomain(content[i].(api.EventSubscription))
+	}
+	return transformed, nil
+}
+
+//CreateEventSubscription operation creates event notification subscription that delivers
+//events of given types to a given URL. Upon successful creation, UUID of a subscription is returned
+func (c RestClient) CreateEventSubscription(subscription EventSubscription) (*string, error) {
+	path := newAPIPath("/ecx/v3/events/subscriptions")
+	reqBody := mapEventSubscriptionDomainToAPI(subscription)
+	respBody := api.CreateEventSubscriptionResponse{}
+	req := c.R().SetBody(&reqBody).SetResult(&respBody)
+	if err := c.execute("CreateEventSubscription", req, http.MethodPost, path); err != nil {
+		return nil, err
+	}
+	return respBody.UUID, nil
+}
+
+//DeleteEventSubscription deletes event notification subscription with a given UUID
+func (c RestClient) DeleteEventSubscription(uuid string) error {
+	path := newAPIPath("/ecx/v3/events/subscriptions/{subscriptionUUID}", uuid)
+	if err := c.execute("DeleteEventSubscription", c.R(), http.MethodDelete, path); err != nil {
+		return err
+	}
+	return nil
+}
+
+func mapEventSubscriptionDomainToAPI(subscription EventSubscription) api.EventSubscription {
+	return api.EventSubscription{
+		Name:       subscription.Name,
+		URL:        subscription.URL,
+		EventTypes: subscription.EventTypes,
+		Secret:     subscription.Secret,
+	}
+}
+
+func mapEventSubscriptionAPIToDomain(apiSubscription api.EventSubscription) EventSubscription {
+	return EventSubscription{
+		UUID:       apiSubscription.UUID,
+		Name:       apiSubscription.Name,
+		URL:        apiSubscription.URL,
+		EventTypes: apiSubscription.EventTypes,
+		State:      apiSubscription.State,
+	}
+}
@@ -0,0 +1,107 @@
+package ecx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/equinix/ecx-go/v2/internal/api"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetEventSubscriptions(t *testing.T) {
+	//Given
+	respBody := api.EventSubscriptionsResponse{}
+	if err := readJSONData("./test-fixtures/ecx_event_subscriptions_get_resp.json", &respBody); err != nil {
+		assert.Failf(t, "Cannot read test response due to %s", err.Error())
+	}
+	pageSize := IntValue(respBody.PageSize)
+	testHc := &http.Client{}
+	httpmock.ActivateNonDefault(testHc)
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ecx/v3/events/subscriptions?pageSize=%d", baseURL, pageSize),
+		func(r *http.Request) (*http.Response, error) {
+			resp, _ := httpmock.NewJsonResponse(200, respBody)
+			return resp, nil
+		},
+	)
+	defer httpmock.DeactivateAndReset()
+
+	//When
+	ecxClient := NewClient(context.Background(), baseURL, testHc)
+	ecxClient.SetPageSize(pageSize)
+	subscriptions, err := ecxClient.GetEventSubscriptions()
+
+	//Then
+	assert.Nil(t, err, "Client should not return an error")
+	assert.Equal(t, len(respBody.Content), len(subscriptions), "Number of subscriptions matches")
+	for i := range respBody.Content {
+		verifyEventSubscription(t, subscriptions[i], respBody.Content[i])
+	}
+}
+
+func TestCreateEventSubscription(t *testing.T) {
+	//Given
+	respBody := api.CreateEventSubscriptionResponse{}
+	if err := readJSONData("./test-fixtures/ecx_event_subscription_post_resp.json", &respBody); err != nil {
+		assert.Failf(t, "Cannot read test response due to %s", err.Error())
+	}
+	reqBody := api.EventSubscription{}
+	testHc := &http.Client{}
+	httpmock.ActivateNonDefault(testHc)
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/ecx/v3/events/subscriptions", baseURL),
+		func(r *http.Request) (*http.Response, error) {
+			if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+				return httpmock.NewStringResponse(400, ""), nil
+			}
+			resp, _ := httpmock.NewJsonResponse(201, respBody)
+			return resp, nil
+		},
+	)
+	defer httpmock.DeactivateAndReset()
+	subscription := EventSubscription{
+		Name:       String("connection-status"),
+		URL:        String("https://hooks.example.com/fabric"),
+		EventTypes: []string{"L2_CONNECTION_STATUS_CHANGED"},
+		Secret:     String("secret"),
+	}
+
+	//When
+	ecxClient := NewClient(context.Background(), baseURL, testHc)
+	uuid, err := ecxClient.CreateEventSubscription(subscription)
+
+	//Then
+	assert.Nil(t, err, "Client should not return an error")
+	assert.Equal(t, respBody.UUID, uuid, "UUID matches")
+	assert.Equal(t, subscription.Name, reqBody.Name, "Name matches")
+	assert.Equal(t, subscription.URL, reqBody.URL, "URL matches")
+	assert.ElementsMatch(t, subscription.EventTypes, reqBody.EventTypes, "EventTypes match")
+	assert.Equal(t, subscription.Secret, reqBody.Secret, "Secret matches")
+}
+
+func TestDeleteEventSubscription(t *testing.T) {
+	//Given
+	uuid := "subscriptionId"
+	testHc := &http.Client{}
+	httpmock.ActivateNonDefault(testHc)
+	httpmock.RegisterResponder("DELETE", fmt.Sprintf("%s/ecx/v3/events/subscriptions/%s", baseURL, uuid),
+		httpmock.NewStringResponder(204, ""))
+	defer httpmock.DeactivateAndReset()
+
+	//When
+	ecxClient := NewClient(context.Background(), baseURL, testHc)
+	err := ecxClient.DeleteEventSubscription(uuid)
+
+	//Then
+	assert.Nil(t, err, "Client should not return an error")
+}
+
+func verifyEventSubscription(t *testing.T, subscription EventSubscription, apiSubscription api.EventSubscription) {
+	assert.Equal(t, apiSubscription.UUID, subscription.UUID, "UUID matches")
+	assert.Equal(t, apiSubscription.Name, subscription.Name, "Name matches")
+	assert.Equal(t, apiSubscription.URL, subscription.URL, "URL matches")
+	assert.ElementsMatch(t, apiSubscription.EventTypes, subscription.EventTypes, "EventTypes match")
+	assert.Equal(t, apiSubscription.State, subscription.State, "State matches")
+}
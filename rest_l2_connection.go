@@ -2,7 +2,6 @@ package ecx
 
 import (
 	"net/http"
-	"net/url"
 
 	"github.com/equinix/ecx-go/v2/internal/api"
 	"github.com/equinix/rest-go"
@@ -19,7 +18,7 @@ type restL2ConnectionUpdateRequest struct {
 //GetL2OutgoingConnections retrieves list of all originating (a-side) layer 2 connections
 //for a customer account associated with authenticated application
 func (c RestClient) GetL2OutgoingConnections(statuses []string) ([]L2Connection, error) {
-	path := "/ecx/v3/l2/buyer/connections"
+	path := newAPIPath("/ecx/v3/l2/buyer/connections")
 	pagingConfig := rest.DefaultPagingConfig().
 		SetSizeParamName("pageSize").
 		SetPageParamName("pageNumber").
@@ -27,7 +26,9 @@ func (c RestClient) GetL2OutgoingConnections(statuses []string) ([]L2Connection,
 	if len(statuses) > 0 {
 		pagingConfig.SetAdditionalParams(map[string]string{"status": buildQueryParamValueString(statuses)})
 	}
-	content, err := c.GetPaginated(path, &api.L2BuyerConnectionsResponse{}, pagingConfig)
+	content, err := c.getPaginated("GetL2OutgoingConnections", path, &api.L2BuyerConnectionsResponse{}, pagingConfig, func(item interface{}) string {
+		return StringValue(item.(api.L2ConnectionResponse).UUID)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -40,10 +41,10 @@ func (c RestClient) GetL2OutgoingConnections(statuses []string) ([]L2Connection,
 
 //GetL2Connection operation retrieves layer 2 connection with a given UUID
 func (c RestClient) GetL2Connection(uuid string) (*L2Connection, error) {
-	path := "/ecx/v3/l2/connections/" + url.PathEscape(uuid)
+	path := newAPIPath("/ecx/v3/l2/connections/{connectionUUID}", uuid)
 	respBody := api.L2ConnectionResponse{}
 	req := c.R().SetResult(&respBody)
-	if err := c.Execute(req, http.MethodGet, path); err != nil {
+	if err := c.execute("GetL2Connection", req, http.MethodGet, path); err != nil {
 		return nil, err
 	}
 	return mapGETToL2Connection(respBody), nil
@@ -52,17 +53,12 @@ func (c RestClient) GetL2Connection(uuid string) (*L2Connection, error) {
 //CreateL2Connection operation creates non-redundant layer 2 connection with a given connection structure.
 //Upon successful creation, connection structure, enriched with assigned UUID, will be returned
 func (c RestClient) CreateL2Connection(l2connection L2Connection) (*string, error) {
-	path := "/ecx/v3/l2/connections"
+	path := newAPIPath("/ecx/v3/l2/connections")
 	if StringValue(l2connection.DeviceUUID) != "" {
-		path = "/ne/v1/l2/connections"
+		path = newAPIPath("/ne/v1/l2/connections")
 	}
-	reqBody := createL2ConnectionRequest(l2connection)
-	respBody := api.CreateL2ConnectionResponse{}
-	req := c.R().SetBody(&reqBody).SetResult(&respBody)
-	if err := c.Execute(req, http.MethodPost, path); err != nil {
-		return nil, err
-	}
-	return respBody.PrimaryConnectionID, nil
+	primaryUUID, _, err := c.postL2Connection("CreateL2Connection", path, createL2ConnectionRequest(l2connection))
+	return primaryUUID, err
 }
 
 //CreateL2RedundantConnection operation creates redundant layer2 connection with
@@ -72,14 +68,19 @@ func (c RestClient) CreateL2Connection(l2connection L2Connection) (*string, erro
 //Upon successful creation, primary connection structure, enriched with assigned UUID
 //and redundant connection UUID, will be returned
 func (c RestClient) CreateL2RedundantConnection(primary L2Connection, secondary L2Connection) (*string, *string, error) {
-	path := "/ecx/v3/l2/connections"
+	path := newAPIPath("/ecx/v3/l2/connections")
 	if StringValue(primary.DeviceUUID) != "" {
-		path = "/ne/v1/l2/connections"
+		path = newAPIPath("/ne/v1/l2/connections")
 	}
-	reqBody := createL2RedundantConnectionRequest(primary, secondary)
+	return c.postL2Connection("CreateL2RedundantConnection", path, createL2RedundantConnectionRequest(primary, secondary))
+}
+
+//postL2Connection sends a given connection creation request to a given path
+//and returns UUIDs of created primary and secondary connections
+func (c RestClient) postL2Connection(operation string, path apiPath, reqBody api.L2ConnectionRequest) (*string, *string, error) {
 	respBody := api.CreateL2ConnectionResponse{}
 	req := c.R().SetBody(&reqBody).SetResult(&respBody)
-	if err := c.Execute(req, http.MethodPost, path); err != nil {
+	if err := c.execute(operation, req, http.MethodPost, path); err != nil {
 		return nil, nil, err
 	}
 	return respBody.PrimaryConnectionID, respBody.SecondaryConnectionID, nil
@@ -87,10 +88,10 @@ func (c RestClient) CreateL2RedundantConnection(primary L2Connection, secondary
 
 //DeleteL2Connection deletes layer 2 connection with a given UUID
 func (c RestClient) DeleteL2Connection(uuid string) error {
-	path := "/ecx/v3/l2/connections/" + url.PathEscape(uuid)
+	path := newAPIPath("/ecx/v3/l2/connections/{connectionUUID}", uuid)
 	respBody := api.DeleteL2ConnectionResponse{}
 	req := c.R().SetResult(&respBody)
-	if err := c.Execute(req, http.MethodDelete, path); err != nil {
+	if err := c.execute("DeleteL2Connection", req, http.MethodDelete, path); err != nil {
 		return err
 	}
 	return nil
@@ -134,7 +135,12 @@ func (req *restL2ConnectionUpdateRequest) WithSpeedUnit(speedUnit string) L2Conn
 //This is not atomic operation and if any update will fail, other changes won't be reverted.
 //UpdateError will be returned if any of requested data failed to update
 func (req *restL2ConnectionUpdateRequest) Execute() error {
-	path := "/ecx/v3/l2/connections/" + url.PathEscape(req.uuid)
+	if req.speed != nil && req.speedUnit != nil {
+		if _, err := NewBandwidth(*req.speed, *req.speedUnit); err != nil {
+			return err
+		}
+	}
+	path := newAPIPath("/ecx/v3/l2/connections/{connectionUUID}", req.uuid)
 	reqBody := api.L2ConnectionUpdateRequest{
 		Name:      req.name,
 		Speed:     req.speed,
@@ -142,7 +148,7 @@ func (req *restL2ConnectionUpdateRequest) Execute() error {
 	}
 	if StringValue(req.name) != "" || (IntValue(req.speed) > 0 && StringValue(req.speedUnit) != "") {
 		restReq := req.c.R().SetQueryParam("action", "update").SetBody(&reqBody)
-		if err := req.c.Execute(restReq, http.MethodPatch, path); err != nil {
+		if err := req.c.execute("UpdateL2Connection", restReq, http.MethodPatch, path); err != nil {
 			return err
 		}
 	}
@@ -178,6 +184,8 @@ func mapGETToL2Connection(getResponse api.L2ConnectionResponse) *L2Connection {
 		Actions:             mapL2ConnectionActionsAPIToDomain(getResponse.ActionDetails),
 		ServiceToken:        getResponse.VendorToken,
 		VendorToken:         getResponse.VendorToken,
+		ZSideDeviceUUID:     getResponse.ZSideDeviceUUID,
+		ZSideInterfaceID:    getResponse.ZSideInterfaceID,
 	}
 }
 
@@ -199,6 +207,8 @@ func createL2ConnectionRequest(l2connection L2Connection) api.L2ConnectionReques
 		PrimaryZSidePortUUID:     l2connection.ZSidePortUUID,
 		PrimaryZSideVlanSTag:     l2connection.ZSideVlanSTag,
 		PrimaryZSideVlanCTag:     l2connection.ZSideVlanCTag,
+		PrimaryZSideDeviceUUID:   l2connection.ZSideDeviceUUID,
+		PrimaryZSideInterfaceID:  l2connection.ZSideInterfaceID,
 		SellerRegion:             l2connection.SellerRegion,
 		SellerMetroCode:          l2connection.SellerMetroCode,
 		AuthorizationKey:         l2connection.AuthorizationKey,
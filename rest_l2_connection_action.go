@@ -0,0 +1,92 @@
+package ecx
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+)
+
+//validationPlaceholderPattern matches validation patterns that are symbolic names
+//of server side rules, i.e. {RegexForString50chars}, rather than regular expressions
+var validationPlaceholderPattern = regexp.MustCompile(`^\{[A-Za-z0-9_]+\}$`)
+
+//PerformL2ConnectionAction responds to a pending action of a layer 2 connection with a given UUID.
+//Given data values are validated against action's required data validation patterns before
+//being submitted. Upon successful submission, updated connection is returned
+func (c RestClient) PerformL2ConnectionAction(uuid string, operationID string, data map[string]string) (*L2Connection, error) {
+	conn, err := c.GetL2Connection(uuid)
+	if err != nil {
+		return nil, err
+	}
+	action := findL2ConnectionAction(conn.Actions, operationID)
+	if action == nil {
+		return nil, fmt.Errorf("connection %q has no pending action with operation identifier %q", uuid, operationID)
+	}
+	if err := validateL2ConnectionActionData(*action, data); err != nil {
+		return nil, err
+	}
+	path := newAPIPath("/ecx/v3/l2/connections/{connectionUUID}", uuid)
+	req := c.R().SetQueryParam("action", operationID).SetBody(data)
+	if err := c.execute("PerformL2ConnectionAction", req, http.MethodPatch, path); err != nil {
+		return nil, err
+	}
+	return c.GetL2Connection(uuid)
+}
+
+func findL2ConnectionAction(actions []L2ConnectionAction, operationID string) *L2ConnectionAction {
+	for i := range actions {
+		if StringValue(actions[i].OperationID) == operationID {
+			return &actions[i]
+		}
+	}
+	return nil
+}
+
+func validateL2ConnectionActionData(action L2ConnectionAction, data map[string]string) error {
+	required := make(map[string]L2ConnectionActionData, len(action.RequiredData))
+	for _, item := range action.RequiredData {
+		required[StringValue(item.Key)] = item
+	}
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		item, ok := required[key]
+		if !ok {
+			return fmt.Errorf("action %q does not accept data with key %q", StringValue(action.OperationID), key)
+		}
+		if !BoolValue(item.IsEditable) && data[key] != StringValue(item.Value) {
+			return fmt.Errorf("action %q data with key %q is not editable", StringValue(action.OperationID), key)
+		}
+		if err := validateL2ConnectionActionValue(item, data[key]); err != nil {
+			return err
+		}
+	}
+	for _, item := range action.RequiredData {
+		key := StringValue(item.Key)
+		if _, ok := data[key]; !ok && BoolValue(item.IsEditable) && StringValue(item.Value) == "" {
+			return fmt.Errorf("action %q requires data with key %q", StringValue(action.OperationID), key)
+		}
+	}
+	return nil
+}
+
+//validateL2ConnectionActionValue checks if value matches validation pattern as a whole.
+//Patterns that are symbolic names of server side rules are left for the server to validate
+func validateL2ConnectionActionValue(item L2ConnectionActionData, value string) error {
+	pattern := StringValue(item.ValidationPattern)
+	if pattern == "" || validationPlaceholderPattern.MatchString(pattern) {
+		return nil
+	}
+	re, err := regexp.Compile("^(?:" + pattern + ")$")
+	if err != nil {
+		return fmt.Errorf("invalid validation pattern %q for data with key %q: %s", pattern, StringValue(item.Key), err)
+	}
+	if !re.MatchString(value) {
+		return fmt.Errorf("value of %q does not match validation pattern %q", StringValue(item.Key), pattern)
+	}
+	return nil
+}
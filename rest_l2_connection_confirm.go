@@ -2,21 +2,20 @@ package ecx
 
 import (
 	"net/http"
-	"net/url"
 
 	"github.com/equinix/ecx-go/v2/internal/api"
 )
 
 //ConfirmL2Connection operation accepts a hosted connection
 func (c RestClient) ConfirmL2Connection(uuid string, connToConfirm L2ConnectionToConfirm) (*L2ConnectionConfirmation, error) {
-	path := "/ecx/v3/l2/connections/" + url.PathEscape(uuid)
+	path := newAPIPath("/ecx/v3/l2/connections/{connectionUUID}", uuid)
 	reqBody := confirmL2ConnectionRequest(connToConfirm)
 	respBody := api.ConfirmL2ConnectionResponse{}
 	req := c.R().
 		SetQueryParam("action", "Approve").
 		SetBody(&reqBody).
 		SetResult(&respBody)
-	if err := c.Execute(req, http.MethodPatch, path); err != nil {
+	if err := c.execute("ConfirmL2Connection", req, http.MethodPatch, path); err != nil {
 		return nil, err
 	}
 
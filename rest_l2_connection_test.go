@@ -214,6 +214,43 @@ func TestCreateRedundantL2Connection(t *testing.T) {
 	assert.Equal(t, secUUID, respBody.SecondaryConnectionID, "RedundantUUID matches")
 }
 
+func TestCreateRedundantL2Connection_secondaryServiceToken(t *testing.T) {
+	//Given
+	respBody := api.CreateL2ConnectionResponse{}
+	if err := readJSONData("./test-fixtures/ecx_l2connection_post_resp.json", &respBody); err != nil {
+		assert.Failf(t, "Cannot read test response due to %s", err.Error())
+	}
+	reqBody := api.L2ConnectionRequest{}
+	testHc := &http.Client{}
+	httpmock.ActivateNonDefault(testHc)
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/ne/v1/l2/connections", baseURL),
+		func(r *http.Request) (*http.Response, error) {
+			if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+				return httpmock.NewStringResponse(400, ""), nil
+			}
+			resp, _ := httpmock.NewJsonResponse(200, respBody)
+			return resp, nil
+		},
+	)
+	defer httpmock.DeactivateAndReset()
+	newPriConn := testPrimaryConnection
+	newPriConn.PortUUID = nil
+	newPriConn.DeviceUUID = String("deviceUUID")
+	newSecConn := L2Connection{
+		Name:         String("secName"),
+		ServiceToken: String("secToken"),
+	}
+
+	//When
+	ecxClient := NewClient(context.Background(), baseURL, testHc)
+	_, _, err := ecxClient.CreateL2RedundantConnection(newPriConn, newSecConn)
+
+	//Then
+	assert.Nil(t, err, "Client should not return an error")
+	assert.Nil(t, reqBody.SecondaryVirtualDeviceUUID, "Secondary device is not set")
+	assert.Equal(t, newSecConn.ServiceToken, reqBody.SecondaryServiceToken, "Secondary service token matches")
+}
+
 func TestDeleteL2Connection(t *testing.T) {
 	//Given
 	respBody := api.DeleteL2ConnectionResponse{}
@@ -276,6 +313,23 @@ func TestUpdateL2Connection(t *testing.T) {
 	assert.Equal(t, newSpeedUnit, StringValue(reqBody.SpeedUnit), "SpeedUnit matches")
 }
 
+func TestUpdateL2Connection_invalidSpeedUnit(t *testing.T) {
+	//Given
+	testHc := &http.Client{}
+	httpmock.ActivateNonDefault(testHc)
+	defer httpmock.DeactivateAndReset()
+
+	//When
+	c := NewClient(context.Background(), baseURL, testHc)
+	err := c.NewL2ConnectionUpdateRequest("connId").
+		WithBandwidth(1, "TB").
+		Execute()
+
+	//Then
+	assert.NotNil(t, err, "Client should return an error")
+	assert.Equal(t, 0, httpmock.GetTotalCallCount(), "No request was sent")
+}
+
 func verifyL2Connection(t *testing.T, conn L2Connection, resp api.L2ConnectionResponse) {
 	assert.Equal(t, resp.UUID, conn.UUID, "UUID matches")
 	assert.Equal(t, resp.Name, conn.Name, "Name matches")
@@ -294,6 +348,8 @@ func verifyL2Connection(t *testing.T, conn L2Connection, resp api.L2ConnectionRe
 	assert.Equal(t, resp.ZSidePortUUID, conn.ZSidePortUUID, "PrimaryZSidePortUUID matches")
 	assert.Equal(t, resp.ZSideVlanSTag, conn.ZSideVlanSTag, "PrimaryZSideVlanSTag matches")
 	assert.Equal(t, resp.ZSideVlanCTag, conn.ZSideVlanCTag, "PrimaryZSideVlanCTag matches")
+	assert.Equal(t, resp.ZSideDeviceUUID, conn.ZSideDeviceUUID, "ZSideDeviceUUID matches")
+	assert.Equal(t, resp.ZSideInterfaceID, conn.ZSideInterfaceID, "ZSideInterfaceID matches")
 	assert.Equal(t, resp.SellerMetroCode, conn.SellerMetroCode, "SellerMetroCode matches")
 	assert.Equal(t, resp.AuthorizationKey, conn.AuthorizationKey, "AuthorizationKey matches")
 	assert.Equal(t, resp.RedundantUUID, conn.RedundantUUID, "RedundantUUID key matches")
@@ -325,6 +381,8 @@ func verifyL2ConnectionRequest(t *testing.T, conn L2Connection, req api.L2Connec
 	assert.Equal(t, conn.ZSidePortUUID, req.PrimaryZSidePortUUID, "PrimaryZSidePortUUID matches")
 	assert.Equal(t, conn.ZSideVlanSTag, req.PrimaryZSideVlanSTag, "PrimaryZSideVlanSTag matches")
 	assert.Equal(t, conn.ZSideVlanCTag, req.PrimaryZSideVlanCTag, "PrimaryZSideVlanCTag matches")
+	assert.Equal(t, conn.ZSideDeviceUUID, req.PrimaryZSideDeviceUUID, "PrimaryZSideDeviceUUID matches")
+	assert.Equal(t, conn.ZSideInterfaceID, req.PrimaryZSideInterfaceID, "PrimaryZSideInterfaceID matches")
 	assert.Equal(t, conn.SellerRegion, req.SellerRegion, "SellerRegion matches")
 	assert.Equal(t, conn.SellerMetroCode, req.SellerMetroCode, "SellerMetroCode matches")
 	assert.Equal(t, conn.AuthorizationKey, req.AuthorizationKey, "AuthorizationKey matches")
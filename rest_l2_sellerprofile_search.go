@@ -0,0 +1,137 @@
+package ecx
+
+import (
+	"sort"
+	"strings"
+)
+
+const (
+	sellerProfileScoreNameExact      = 6
+	sellerProfileScoreNamePrefix     = 4
+	sellerProfileScoreNameContains   = 3
+	sellerProfileScoreOrgExact       = 3
+	sellerProfileScoreOrgPrefix      = 2
+	sellerProfileScoreOrgContains    = 1
+	sellerProfileScoreSpeedBand      = 2
+	sellerProfileScoreCustomSpeed    = 1
+	sellerProfileMetroCodeQueryParam = "metroCode"
+)
+
+type rankedL2SellerProfile struct {
+	profile L2ServiceProfile
+	score   int
+}
+
+//SearchL2SellerProfiles retrieves layer 2 seller service profiles that match all criteria
+//of a given query. Metro code is used as a query parameter, remaining criteria are applied
+//on retrieved profiles. Profiles are ordered from the best matching one: exact name matches
+//rank higher than partial and organization matches, speed band matches rank higher
+//than profiles that allow custom speed
+func (c RestClient) SearchL2SellerProfiles(query L2SellerProfileQuery) ([]L2ServiceProfile, error) {
+	var params map[string]string
+	if query.MetroCode != "" {
+		params = map[string]string{sellerProfileMetroCodeQueryParam: query.MetroCode}
+	}
+	profiles, err := c.getL2SellerProfiles("SearchL2SellerProfiles", params)
+	if err != nil {
+		return nil, err
+	}
+	return rankL2SellerProfiles(profiles, query), nil
+}
+
+func rankL2SellerProfiles(profiles []L2ServiceProfile, query L2SellerProfileQuery) []L2ServiceProfile {
+	ranked := make([]rankedL2SellerProfile, 0, len(profiles))
+	for _, profile := range profiles {
+		if score, ok := matchL2SellerProfile(profile, query); ok {
+			ranked = append(ranked, rankedL2SellerProfile{profile: profile, score: score})
+		}
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].score != ranked[j].score {
+			return ranked[i].score > ranked[j].score
+		}
+		return strings.ToLower(StringValue(ranked[i].profile.Name)) < strings.ToLower(StringValue(ranked[j].profile.Name))
+	})
+	result := make([]L2ServiceProfile, len(ranked))
+	for i := range ranked {
+		result[i] = ranked[i].profile
+	}
+	return result
+}
+
+func matchL2SellerProfile(profile L2ServiceProfile, query L2SellerProfileQuery) (int, bool) {
+	score := 0
+	if query.Name != "" {
+		nameScore := matchL2SellerProfileName(profile, query.Name)
+		if nameScore == 0 {
+			return 0, false
+		}
+		score += nameScore
+	}
+	if query.MetroCode != "" && !hasL2SellerProfileMetro(profile, query.MetroCode) {
+		return 0, false
+	}
+	if query.Bandwidth > 0 {
+		switch {
+		case hasL2ServiceProfileSpeedBand(profile, query.Bandwidth):
+			score += sellerProfileScoreSpeedBand
+		case BoolValue(profile.AllowCustomSpeed):
+			score += sellerProfileScoreCustomSpeed
+		default:
+			return 0, false
+		}
+	}
+	if query.Encapsulation != "" && !strings.EqualFold(StringValue(profile.Encapsulation), query.Encapsulation) {
+		return 0, false
+	}
+	if query.CloudReach != nil && BoolValue(profile.Features.CloudReach) != *query.CloudReach {
+		return 0, false
+	}
+	return score, true
+}
+
+func matchL2SellerProfileName(profile L2ServiceProfile, name string) int {
+	score := matchText(StringValue(profile.Name), name,
+		sellerProfileScoreNameExact, sellerProfileScoreNamePrefix, sellerProfileScoreNameContains)
+	for _, org := range []*string{profile.OrganizationName, profile.GlobalOrganization} {
+		if orgScore := matchText(StringValue(org), name,
+			sellerProfileScoreOrgExact, sellerProfileScoreOrgPrefix, sellerProfileScoreOrgContains); orgScore > score {
+			score = orgScore
+		}
+	}
+	return score
+}
+
+func matchText(text string, search string, exact int, prefix int, contains int) int {
+	text = strings.ToLower(text)
+	search = strings.ToLower(search)
+	switch {
+	case text == "":
+		return 0
+	case text == search:
+		return exact
+	case strings.HasPrefix(text, search):
+		return prefix
+	case strings.Contains(text, search):
+		return contains
+	}
+	return 0
+}
+
+func hasL2SellerProfileMetro(profile L2ServiceProfile, metroCode string) bool {
+	for _, metro := range profile.Metros {
+		if strings.EqualFold(StringValue(metro.Code), metroCode) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasL2ServiceProfileSpeedBand(profile L2ServiceProfile, bandwidth Bandwidth) bool {
+	for _, band := range profile.SpeedBands {
+		if bandBandwidth, err := speedBandwidth(band.Speed, band.SpeedUnit); err == nil && bandBandwidth == bandwidth {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,115 @@
+package ecx
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/equinix/ecx-go/v2/internal/api"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func readSellerProfilesTestData(t *testing.T) []L2ServiceProfile {
+	respBody := api.L2SellerProfilesResponse{}
+	if err := readJSONData("./test-fixtures/ecx_l2sellerprofile_get.json", &respBody); err != nil {
+		assert.Failf(t, "Cannot read test response due to %s", err.Error())
+	}
+	profiles := make([]L2ServiceProfile, len(respBody.Content))
+	for i := range respBody.Content {
+		profiles[i] = *mapL2ServiceProfileAPIToDomain(respBody.Content[i])
+	}
+	return profiles
+}
+
+func profileNames(profiles []L2ServiceProfile) []string {
+	names := make([]string, len(profiles))
+	for i := range profiles {
+		names[i] = StringValue(profiles[i].Name)
+	}
+	return names
+}
+
+func TestSearchL2SellerProfiles(t *testing.T) {
+	//Given
+	respBody := api.L2SellerProfilesResponse{}
+	if err := readJSONData("./test-fixtures/ecx_l2sellerprofile_get.json", &respBody); err != nil {
+		assert.Failf(t, "Cannot read test response due to %s", err.Error())
+	}
+	pageSize := 20
+	query := url.Values{
+		"pageSize":  []string{fmt.Sprint(pageSize)},
+		"metroCode": []string{"DC"},
+	}
+	testHc := &http.Client{}
+	httpmock.ActivateNonDefault(testHc)
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ecx/v3/l2/serviceprofiles/services?%s", baseURL, query.Encode()),
+		func(r *http.Request) (*http.Response, error) {
+			resp, _ := httpmock.NewJsonResponse(200, respBody)
+			return resp, nil
+		},
+	)
+	defer httpmock.DeactivateAndReset()
+
+	//When
+	ecxClient := NewClient(context.Background(), baseURL, testHc)
+	ecxClient.SetPageSize(pageSize)
+	profiles, err := ecxClient.SearchL2SellerProfiles(L2SellerProfileQuery{
+		Name:      "aws",
+		MetroCode: "DC",
+		Bandwidth: 500 * Megabit,
+	})
+
+	//Then
+	assert.Nil(t, err, "Client should not return an error")
+	assert.Equal(t, []string{"AWS Direct Connect"}, profileNames(profiles), "Matching profiles are returned")
+}
+
+func TestRankL2SellerProfiles(t *testing.T) {
+	profiles := readSellerProfilesTestData(t)
+	tests := []struct {
+		name     string
+		query    L2SellerProfileQuery
+		expected []string
+	}{
+		{
+			"exact name ranks first",
+			L2SellerProfileQuery{Name: "Google Cloud Partner Interconnect Zone 2"},
+			[]string{"Google Cloud Partner Interconnect Zone 2"},
+		},
+		{
+			"name prefix ranks above organization",
+			L2SellerProfileQuery{Name: "gen"},
+			[]string{"GENSELLER-L2-SP", "L2-QnQ-Red-Genseller"},
+		},
+		{
+			"speed band ranks above custom speed",
+			L2SellerProfileQuery{Bandwidth: 5 * Gigabit},
+			[]string{
+				"Google Cloud Partner Interconnect Zone 1", "Google Cloud Partner Interconnect Zone 2",
+				"IBM Cloud Direct Link Exchange", "L2-QnQ-Red-Genseller", "SP_Reg_Test_Edit_01",
+			},
+		},
+		{
+			"encapsulation and metro",
+			L2SellerProfileQuery{Encapsulation: "QINQ", MetroCode: "sy"},
+			[]string{"tf-priv-qinq", "tf-priv-qinq"},
+		},
+		{
+			"cloud reach",
+			L2SellerProfileQuery{CloudReach: Bool(false)},
+			[]string{},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//When
+			ranked := rankL2SellerProfiles(profiles, tc.query)
+
+			//Then
+			assert.Equal(t, tc.expected, profileNames(ranked), "Ranked profiles match")
+		})
+	}
+}
@@ -3,7 +3,6 @@ package ecx
 import (
 	"fmt"
 	"net/http"
-	"net/url"
 
 	"github.com/equinix/ecx-go/v2/internal/api"
 	"github.com/equinix/rest-go"
@@ -11,11 +10,19 @@ import (
 
 //GetL2SellerProfiles operations retrieves available layer2 seller service profiles
 func (c RestClient) GetL2SellerProfiles() ([]L2ServiceProfile, error) {
-	path := "/ecx/v3/l2/serviceprofiles/services"
-	content, err := c.GetPaginated(path, &api.L2SellerProfilesResponse{}, rest.DefaultPagingConfig().
+	return c.getL2SellerProfiles("GetL2SellerProfiles", nil)
+}
+
+func (c RestClient) getL2SellerProfiles(operation string, params map[string]string) ([]L2ServiceProfile, error) {
+	path := newAPIPath("/ecx/v3/l2/serviceprofiles/services")
+	pagingConfig := rest.DefaultPagingConfig().
 		SetSizeParamName("pageSize").
 		SetPageParamName("pageNumber").
-		SetFirstPageNumber(0))
+		SetFirstPageNumber(0)
+	if len(params) > 0 {
+		pagingConfig.SetAdditionalParams(params)
+	}
+	content, err := c.getPaginated(operation, path, &api.L2SellerProfilesResponse{}, pagingConfig, l2ServiceProfileKey)
 	if err != nil {
 		return nil, err
 	}
@@ -26,25 +33,50 @@ func (c RestClient) GetL2SellerProfiles() ([]L2ServiceProfile, error) {
 	return transformed, nil
 }
 
+//GetL2OwnServiceProfiles operation retrieves layer 2 service profiles owned by customer account
+//associated with authenticated application, including private and not approved ones.
+//When states are given, only profiles in one of given states are returned
+func (c RestClient) GetL2OwnServiceProfiles(states []string) ([]L2ServiceProfile, error) {
+	path := newAPIPath("/ecx/v3/l2/serviceprofiles")
+	pagingConfig := rest.DefaultPagingConfig().
+		SetSizeParamName("pageSize").
+		SetPageParamName("pageNumber").
+		SetFirstPageNumber(0)
+	if len(states) > 0 {
+		pagingConfig.SetAdditionalParams(map[string]string{"state": buildQueryParamValueString(states)})
+	}
+	content, err := c.getPaginated("GetL2OwnServiceProfiles", path, &api.L2ServiceProfilesResponse{}, pagingConfig, l2ServiceProfileKey)
+	if err != nil {
+		return nil, err
+	}
+	transformed := make([]L2ServiceProfile, 0, len(content))
+	for i := range content {
+		profile := mapL2ServiceProfileAPIToDomain(content[i].(api.L2ServiceProfile))
+		if len(states) > 0 && !isStringInSlice(StringValue(profile.State), states) {
+			continue
+		}
+		transformed = append(transformed, *profile)
+	}
+	return transformed, nil
+}
+
 //GetL2ServiceProfile operation retrieves layer 2 servie profile with a given UUID
 func (c RestClient) GetL2ServiceProfile(uuid string) (*L2ServiceProfile, error) {
-	path := "/ecx/v3/l2/serviceprofiles/" + url.PathEscape(uuid)
-	respBody := api.L2ServiceProfile{}
-	req := c.R().SetResult(&respBody)
-	if err := c.Execute(req, http.MethodGet, path); err != nil {
+	respBody, err := c.getL2ServiceProfileAPI(uuid)
+	if err != nil {
 		return nil, err
 	}
-	return mapL2ServiceProfileAPIToDomain(respBody), nil
+	return mapL2ServiceProfileAPIToDomain(*respBody), nil
 }
 
 //CreateL2ServiceProfile operation creates layer 2 service profile with a given profile structure.
 //Upon successful creation, connection structure with assigned UUID will be returned
 func (c RestClient) CreateL2ServiceProfile(l2profile L2ServiceProfile) (*string, error) {
-	path := "/ecx/v3/l2/serviceprofiles"
+	path := newAPIPath("/ecx/v3/l2/serviceprofiles")
 	reqBody := mapL2ServiceProfileDomainToAPI(l2profile)
 	respBody := api.CreateL2ServiceProfileResponse{}
 	req := c.R().SetBody(&reqBody).SetResult(&respBody)
-	if err := c.Execute(req, http.MethodPost, path); err != nil {
+	if err := c.execute("CreateL2ServiceProfile", req, http.MethodPost, path); err != nil {
 		return nil, err
 	}
 	return respBody.UUID, nil
@@ -56,11 +88,11 @@ func (c RestClient) UpdateL2ServiceProfile(sp L2ServiceProfile) error {
 	if StringValue(sp.UUID) == "" {
 		return fmt.Errorf("target profile structure needs to have UUID defined")
 	}
-	path := "/ecx/v3/l2/serviceprofiles"
+	path := newAPIPath("/ecx/v3/l2/serviceprofiles")
 	reqBody := mapL2ServiceProfileDomainToAPI(sp)
 	respBody := api.CreateL2ServiceProfileResponse{}
 	req := c.R().SetBody(&reqBody).SetResult(&respBody)
-	if err := c.Execute(req, http.MethodPut, path); err != nil {
+	if err := c.execute("UpdateL2ServiceProfile", req, http.MethodPut, path); err != nil {
 		return err
 	}
 	return nil
@@ -68,15 +100,24 @@ func (c RestClient) UpdateL2ServiceProfile(sp L2ServiceProfile) error {
 
 //DeleteL2ServiceProfile deletes layer 2 service profile with a given UUID
 func (c RestClient) DeleteL2ServiceProfile(uuid string) error {
-	path := "/ecx/v3/l2/serviceprofiles/" + url.PathEscape(uuid)
+	path := newAPIPath("/ecx/v3/l2/serviceprofiles/{profileUUID}", uuid)
 	respBody := api.L2ServiceProfileDeleteResponse{}
 	req := c.R().SetResult(&respBody)
-	if err := c.Execute(req, http.MethodDelete, path); err != nil {
+	if err := c.execute("DeleteL2ServiceProfile", req, http.MethodDelete, path); err != nil {
 		return err
 	}
 	return nil
 }
 
+//SupportsBandwidth checks if connection with a given bandwidth can be created using
+//the profile, either because it matches one of profile's speed bands or because
+//profile allows custom speed
+func (sp L2ServiceProfile) SupportsBandwidth(bandwidth Bandwidth) bool {
+	return BoolValue(sp.AllowCustomSpeed) || hasL2ServiceProfileSpeedBand(sp, bandwidth)
+}
+
+//mapL2ServiceProfileDomainToAPI maps writable profile fields, fields assigned by the server
+//(metros and organization details) are not sent
 func mapL2ServiceProfileDomainToAPI(l2profile L2ServiceProfile) api.L2ServiceProfile {
 	return api.L2ServiceProfile{
 		UUID:                                l2profile.UUID,
@@ -106,9 +147,15 @@ func mapL2ServiceProfileDomainToAPI(l2profile L2ServiceProfile) api.L2ServicePro
 		TagType:                             l2profile.TagType,
 		VlanSameAsPrimary:                   l2profile.VlanSameAsPrimary,
 		Description:                         l2profile.Description,
+		AdditionalInfos:                     mapL2SellerProfileAdditionalInfosDomainToAPI(l2profile.AdditionalInfos),
+		ProfileEncapsulation:                l2profile.Encapsulation,
 	}
 }
 
+func l2ServiceProfileKey(item interface{}) string {
+	return StringValue(item.(api.L2ServiceProfile).UUID)
+}
+
 func mapL2ServiceProfileAPIToDomain(apiProfile api.L2ServiceProfile) *L2ServiceProfile {
 	return &L2ServiceProfile{
 		UUID:                                apiProfile.UUID,
@@ -216,6 +263,19 @@ func mapL2SellerProfileMetrosAPIToDomain(apiMetros []api.L2SellerProfileMetro) [
 	return transformed
 }
 
+func mapL2SellerProfileAdditionalInfosDomainToAPI(infos []L2SellerProfileAdditionalInfo) []api.L2SellerProfileAdditionalInfo {
+	transformed := make([]api.L2SellerProfileAdditionalInfo, len(infos))
+	for i := range infos {
+		transformed[i] = api.L2SellerProfileAdditionalInfo{
+			Name:           infos[i].Name,
+			Description:    infos[i].Description,
+			DataType:       infos[i].DataType,
+			Mandatory:      infos[i].IsMandatory,
+			CaptureInEmail: infos[i].IsCaptureInEmail}
+	}
+	return transformed
+}
+
 func mapL2SellerProfileAdditionalInfosAPIToDomain(apiInfos []api.L2SellerProfileAdditionalInfo) []L2SellerProfileAdditionalInfo {
 	transformed := make([]L2SellerProfileAdditionalInfo, len(apiInfos))
 	for i := range apiInfos {
@@ -0,0 +1,48 @@
+package ecx
+
+import (
+	"reflect"
+)
+
+//CloneL2ServiceProfile operation creates new layer 2 service profile based on existing profile
+//with a given UUID. Fields assigned by the server (UUID, state, metros and organization
+//details) are not copied. Fields set in overrides replace corresponding fields of the source
+//profile. Upon successful creation, UUID of a new profile is returned
+func (c RestClient) CloneL2ServiceProfile(sourceUUID string, overrides L2ServiceProfile) (*string, error) {
+	source, err := c.GetL2ServiceProfile(sourceUUID)
+	if err != nil {
+		return nil, err
+	}
+	clone := stripL2ServiceProfileServerFields(*source)
+	applyL2ServiceProfileOverrides(&clone, overrides)
+	return c.CreateL2ServiceProfile(clone)
+}
+
+func stripL2ServiceProfileServerFields(profile L2ServiceProfile) L2ServiceProfile {
+	profile.UUID = nil
+	profile.State = nil
+	profile.Metros = nil
+	profile.OrganizationName = nil
+	profile.GlobalOrganization = nil
+	return profile
+}
+
+//applyL2ServiceProfileOverrides replaces fields of a profile with all non nil pointer
+//and slice fields of overrides, including fields of nested structures
+func applyL2ServiceProfileOverrides(profile *L2ServiceProfile, overrides L2ServiceProfile) {
+	applyOverrides(reflect.ValueOf(profile).Elem(), reflect.ValueOf(overrides))
+}
+
+func applyOverrides(target reflect.Value, overrides reflect.Value) {
+	for i := 0; i < overrides.NumField(); i++ {
+		field := overrides.Field(i)
+		switch field.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Map:
+			if !field.IsNil() {
+				target.Field(i).Set(field)
+			}
+		case reflect.Struct:
+			applyOverrides(target.Field(i), field)
+		}
+	}
+}
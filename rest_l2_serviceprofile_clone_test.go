@@ -0,0 +1,68 @@
+package ecx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/equinix/ecx-go/v2/internal/api"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCloneL2ServiceProfile(t *testing.T) {
+	//Given
+	source := readProfileUpdateTestData(t)
+	source.Metros = []api.L2SellerProfileMetro{{Code: String("LD"), Name: String("London")}}
+	source.GlobalOrganization = String("globalOrg")
+	respBody := api.CreateL2ServiceProfileResponse{}
+	if err := readJSONData("./test-fixtures/ecx_l2serviceprofile_post_resp.json", &respBody); err != nil {
+		assert.Failf(t, "Cannot read test response due to %s", err.Error())
+	}
+	reqBody := api.L2ServiceProfile{}
+	testHc := &http.Client{}
+	httpmock.ActivateNonDefault(testHc)
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ecx/v3/l2/serviceprofiles/%s", baseURL, StringValue(source.UUID)),
+		func(r *http.Request) (*http.Response, error) {
+			resp, _ := httpmock.NewJsonResponse(200, source)
+			return resp, nil
+		},
+	)
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/ecx/v3/l2/serviceprofiles", baseURL),
+		func(r *http.Request) (*http.Response, error) {
+			if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+				return httpmock.NewStringResponse(400, ""), nil
+			}
+			resp, _ := httpmock.NewJsonResponse(200, respBody)
+			return resp, nil
+		},
+	)
+	defer httpmock.DeactivateAndReset()
+	overrides := L2ServiceProfile{
+		Name:     String("clone"),
+		Ports:    []L2ServiceProfilePort{{ID: String("port-id"), MetroCode: String("AM")}},
+		Features: L2ServiceProfileFeatures{TestProfile: Bool(true)},
+	}
+
+	//When
+	ecxClient := NewClient(context.Background(), baseURL, testHc)
+	uuid, err := ecxClient.CloneL2ServiceProfile(StringValue(source.UUID), overrides)
+
+	//Then
+	assert.Nil(t, err, "Client should not return an error")
+	assert.Equal(t, respBody.UUID, uuid, "UUID matches")
+	assert.Nil(t, reqBody.UUID, "UUID is not copied")
+	assert.Nil(t, reqBody.State, "State is not copied")
+	assert.Empty(t, reqBody.Metros, "Metros are not copied")
+	assert.Nil(t, reqBody.OrganizationName, "OrganizationName is not copied")
+	assert.Nil(t, reqBody.GlobalOrganization, "GlobalOrganization is not copied")
+	assert.Equal(t, "clone", StringValue(reqBody.Name), "Name is overridden")
+	assert.Equal(t, mapPortsDomainToAPI(overrides.Ports), reqBody.Ports, "Ports are overridden")
+	assert.Equal(t, true, BoolValue(reqBody.Features.TestProfile), "TestProfile is overridden")
+	assert.Equal(t, source.Features.CloudReach, reqBody.Features.CloudReach, "CloudReach is copied")
+	assert.Equal(t, source.Description, reqBody.Description, "Description is copied")
+	assert.Equal(t, source.SpeedBands, reqBody.SpeedBands, "SpeedBands are copied")
+	assert.Equal(t, source.OnBandwidthThresholdNotification, reqBody.OnBandwidthThresholdNotification, "Notifications are copied")
+}
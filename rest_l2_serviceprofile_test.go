@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
 	"testing"
 
 	"github.com/equinix/ecx-go/v2/internal/api"
@@ -234,12 +236,14 @@ func verifyL2ServiceProfile(t *testing.T, prof L2ServiceProfile, resp api.L2Serv
 		assert.Equal(t, resp.SpeedBands[i].Speed, prof.SpeedBands[i].Speed, fmt.Sprintf("SpeedBands[%v].Speed matches", i))
 		assert.Equal(t, resp.SpeedBands[i].SpeedUnit, prof.SpeedBands[i].SpeedUnit, fmt.Sprintf("SpeedBands[%v].SpeedUnit matches", i))
 	}
+	assert.Equal(t, len(resp.Metros), len(prof.Metros), "Number of Metros matches")
 	for i := range resp.Metros {
 		assert.Equal(t, resp.Metros[i].Code, prof.Metros[i].Code, fmt.Sprintf("Metros[%v].Code matches", i))
 		assert.Equal(t, resp.Metros[i].Name, prof.Metros[i].Name, fmt.Sprintf("Metros[%v].Name matches", i))
 		assert.ElementsMatch(t, resp.Metros[i].IBXs, prof.Metros[i].IBXes, fmt.Sprintf("Metros[%v].IBXs matches", i))
 		assert.Equal(t, resp.Metros[i].Regions, prof.Metros[i].Regions, fmt.Sprintf("Metros[%v].Regions matches", i))
 	}
+	assert.Equal(t, len(resp.AdditionalInfos), len(prof.AdditionalInfos), "Number of AdditionalInfos matches")
 	for i := range resp.AdditionalInfos {
 		assert.Equal(t, resp.AdditionalInfos[i].Name, prof.AdditionalInfos[i].Name, fmt.Sprintf("AdditionalInfos[%v].Name matches", i))
 		assert.Equal(t, resp.AdditionalInfos[i].Description, prof.AdditionalInfos[i].Description, fmt.Sprintf("AdditionalInfos[%v].Description matches", i))
@@ -252,8 +256,115 @@ func verifyL2ServiceProfile(t *testing.T, prof L2ServiceProfile, resp api.L2Serv
 	assert.Equal(t, resp.GlobalOrganization, prof.GlobalOrganization, "GlobalOrganization matches")
 }
 
+func TestL2ServiceProfileMappingRoundTrip(t *testing.T) {
+	//Given
+	var profiles []api.L2ServiceProfile
+	single := api.L2ServiceProfile{}
+	if err := readJSONData("./test-fixtures/ecx_l2serviceprofile_get_resp.json", &single); err != nil {
+		assert.Failf(t, "Cannot read test response due to %s", err.Error())
+	}
+	profiles = append(profiles, single)
+	for _, fixture := range []string{"ecx_l2sellerprofile_get.json", "ecx_l2serviceprofiles_get_resp.json"} {
+		list := api.L2ServiceProfilesResponse{}
+		if err := readJSONData("./test-fixtures/"+fixture, &list); err != nil {
+			assert.Failf(t, "Cannot read test response due to %s", err.Error())
+		}
+		profiles = append(profiles, list.Content...)
+	}
+	for i := range profiles {
+		//When
+		roundTrip := mapL2ServiceProfileDomainToAPI(*mapL2ServiceProfileAPIToDomain(profiles[i]))
+
+		//Then
+		expected := profiles[i]
+		expected.LastUpdatedDate = nil
+		expected.Metros = nil
+		expected.GlobalOrganization = nil
+		expected.OrganizationName = nil
+		expectedJSON, _ := json.Marshal(expected)
+		roundTripJSON, _ := json.Marshal(roundTrip)
+		assert.JSONEq(t, string(expectedJSON), string(roundTripJSON), "Profile %q survives Get to Update mapping", StringValue(profiles[i].Name))
+	}
+}
+
+func TestMapL2ServiceProfileDomainToAPI_writableFields(t *testing.T) {
+	//Given
+	profile := L2ServiceProfile{
+		UUID: String("profileUUID"),
+		Name: String("profile"),
+		AdditionalInfos: []L2SellerProfileAdditionalInfo{
+			{
+				Name:             String("account"),
+				Description:      String("Cloud account identifier"),
+				DataType:         String("STRING"),
+				IsMandatory:      Bool(true),
+				IsCaptureInEmail: Bool(false),
+			},
+		},
+		Encapsulation:      String("Dot1q"),
+		Metros:             []L2SellerProfileMetro{{Code: String("SV"), Name: String("Silicon Valley")}},
+		OrganizationName:   String("organization"),
+		GlobalOrganization: String("globalOrganization"),
+	}
+
+	//When
+	apiProfile := mapL2ServiceProfileDomainToAPI(profile)
+
+	//Then
+	roundTrip := mapL2ServiceProfileAPIToDomain(apiProfile)
+	assert.Equal(t, profile.AdditionalInfos, roundTrip.AdditionalInfos, "AdditionalInfos survive mapping")
+	assert.Equal(t, profile.Encapsulation, roundTrip.Encapsulation, "Encapsulation survives mapping")
+	assert.Empty(t, apiProfile.Metros, "Server assigned Metros are not mapped")
+	assert.Nil(t, apiProfile.OrganizationName, "Server assigned OrganizationName is not mapped")
+	assert.Nil(t, apiProfile.GlobalOrganization, "Server assigned GlobalOrganization is not mapped")
+}
+
 func verifyL2ServiceProfileUpdate(t *testing.T, prof L2ServiceProfile, req api.L2ServiceProfile) {
 	assert.Equal(t, prof.UUID, req.UUID, "UUID matches")
 	assert.Equal(t, prof.State, req.State, "State matches")
 	verifyL2ServiceProfile(t, prof, req)
 }
+
+func TestGetL2OwnServiceProfiles(t *testing.T) {
+	//Given
+	respBody := api.L2ServiceProfilesResponse{}
+	if err := readJSONData("./test-fixtures/ecx_l2serviceprofiles_get_resp.json", &respBody); err != nil {
+		assert.Failf(t, "Cannot read test response due to %s", err.Error())
+	}
+	pageSize := 2
+	states := []string{"PENDING_APPROVAL"}
+	pages := [][]api.L2ServiceProfile{respBody.Content[:pageSize], respBody.Content[pageSize:]}
+	testHc := &http.Client{}
+	httpmock.ActivateNonDefault(testHc)
+	for i := range pages {
+		query := url.Values{
+			"pageSize": []string{strconv.Itoa(pageSize)},
+			"state":    []string{states[0]},
+		}
+		if i > 0 {
+			query.Set("pageNumber", strconv.Itoa(i))
+		}
+		page := respBody
+		page.PageNumber = Int(i)
+		page.PageSize = Int(pageSize)
+		page.Content = pages[i]
+		httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ecx/v3/l2/serviceprofiles?%s", baseURL, query.Encode()),
+			func(r *http.Request) (*http.Response, error) {
+				resp, _ := httpmock.NewJsonResponse(200, page)
+				return resp, nil
+			},
+		)
+	}
+	defer httpmock.DeactivateAndReset()
+
+	//When
+	ecxClient := NewClient(context.Background(), baseURL, testHc)
+	ecxClient.SetPageSize(pageSize)
+	profiles, err := ecxClient.GetL2OwnServiceProfiles(states)
+
+	//Then
+	assert.Nil(t, err, "Client should not return an error")
+	assert.Equal(t, 2, len(profiles), "Number of profiles matches")
+	verifyL2ServiceProfile(t, profiles[0], respBody.Content[0])
+	verifyL2ServiceProfile(t, profiles[1], respBody.Content[2])
+}
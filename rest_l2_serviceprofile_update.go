@@ -0,0 +1,238 @@
+package ecx
+
+import (
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/equinix/ecx-go/v2/internal/api"
+)
+
+type restL2ServiceProfileUpdateRequest struct {
+	uuid                                string
+	name                                *string
+	description                         *string
+	speedBands                          []L2ServiceProfileSpeedBand
+	onBandwidthThresholdNotification    []string
+	onProfileApprovalRejectNotification []string
+	onVcApprovalRejectionNotification   []string
+	privateUserEmails                   []string
+	ports                               []L2ServiceProfilePort
+	baseline                            *api.L2ServiceProfile
+	c                                   RestClient
+}
+
+//NewL2ServiceProfileUpdateRequest creates new composite update request
+//for a service profile with a given UUID
+func (c RestClient) NewL2ServiceProfileUpdateRequest(uuid string) L2ServiceProfileUpdateRequest {
+	return &restL2ServiceProfileUpdateRequest{
+		uuid: uuid,
+		c:    c,
+	}
+}
+
+//WithName sets new profile name in a composite profile update request
+func (req *restL2ServiceProfileUpdateRequest) WithName(name string) L2ServiceProfileUpdateRequest {
+	req.name = &name
+	return req
+}
+
+//WithDescription sets new profile description in a composite profile update request
+func (req *restL2ServiceProfileUpdateRequest) WithDescription(description string) L2ServiceProfileUpdateRequest {
+	req.description = &description
+	return req
+}
+
+//WithSpeedBands sets new profile speed bands in a composite profile update request
+func (req *restL2ServiceProfileUpdateRequest) WithSpeedBands(bands []L2ServiceProfileSpeedBand) L2ServiceProfileUpdateRequest {
+	req.speedBands = nonNilSpeedBands(bands)
+	return req
+}
+
+//WithOnBandwidthThresholdNotification sets new list of emails notified when bandwidth threshold
+//is exceeded in a composite profile update request
+func (req *restL2ServiceProfileUpdateRequest) WithOnBandwidthThresholdNotification(emails []string) L2ServiceProfileUpdateRequest {
+	req.onBandwidthThresholdNotification = nonNilStrings(emails)
+	return req
+}
+
+//WithOnProfileApprovalRejectNotification sets new list of emails notified when profile is approved
+//or rejected in a composite profile update request
+func (req *restL2ServiceProfileUpdateRequest) WithOnProfileApprovalRejectNotification(emails []string) L2ServiceProfileUpdateRequest {
+	req.onProfileApprovalRejectNotification = nonNilStrings(emails)
+	return req
+}
+
+//WithOnVcApprovalRejectionNotification sets new list of emails notified when connection is approved
+//or rejected in a composite profile update request
+func (req *restL2ServiceProfileUpdateRequest) WithOnVcApprovalRejectionNotification(emails []string) L2ServiceProfileUpdateRequest {
+	req.onVcApprovalRejectionNotification = nonNilStrings(emails)
+	return req
+}
+
+//WithPrivateUserEmails sets new list of emails of users allowed to use private profile
+//in a composite profile update request
+func (req *restL2ServiceProfileUpdateRequest) WithPrivateUserEmails(emails []string) L2ServiceProfileUpdateRequest {
+	req.privateUserEmails = nonNilStrings(emails)
+	return req
+}
+
+//WithPorts sets new profile ports in a composite profile update request
+func (req *restL2ServiceProfileUpdateRequest) WithPorts(ports []L2ServiceProfilePort) L2ServiceProfileUpdateRequest {
+	req.ports = nonNilPorts(ports)
+	return req
+}
+
+//Diff fetches current service profile, unless it was already fetched by the request,
+//and returns changes that will be applied when request is executed
+func (req *restL2ServiceProfileUpdateRequest) Diff() ([]L2ServiceProfileChange, error) {
+	if req.baseline == nil {
+		current, err := req.c.getL2ServiceProfileAPI(req.uuid)
+		if err != nil {
+			return nil, err
+		}
+		req.baseline = current
+	}
+	current := mapL2ServiceProfileAPIToDomain(*req.baseline)
+	return diffL2ServiceProfiles(*current, req.merge(*current)), nil
+}
+
+//Execute applies changes set in composite update request on top of the service profile
+//fetched by the request and replaces the profile with the result.
+//L2ServiceProfileModifiedError is returned if profile was modified after it was fetched.
+//Check is best-effort, see L2ServiceProfileModifiedError
+func (req *restL2ServiceProfileUpdateRequest) Execute() error {
+	changes, err := req.Diff()
+	if err != nil {
+		return err
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	latest, err := req.c.getL2ServiceProfileAPI(req.uuid)
+	if err != nil {
+		return err
+	}
+	if isL2ServiceProfileModified(*req.baseline, *latest) {
+		return L2ServiceProfileModifiedError{UUID: req.uuid}
+	}
+	merged := req.merge(*mapL2ServiceProfileAPIToDomain(*latest))
+	if err := req.c.UpdateL2ServiceProfile(merged); err != nil {
+		return err
+	}
+	req.baseline = nil
+	return nil
+}
+
+func (req *restL2ServiceProfileUpdateRequest) merge(profile L2ServiceProfile) L2ServiceProfile {
+	if req.name != nil {
+		profile.Name = req.name
+	}
+	if req.description != nil {
+		profile.Description = req.description
+	}
+	if req.speedBands != nil {
+		profile.SpeedBands = req.speedBands
+	}
+	if req.onBandwidthThresholdNotification != nil {
+		profile.OnBandwidthThresholdNotification = req.onBandwidthThresholdNotification
+	}
+	if req.onProfileApprovalRejectNotification != nil {
+		profile.OnProfileApprovalRejectNotification = req.onProfileApprovalRejectNotification
+	}
+	if req.onVcApprovalRejectionNotification != nil {
+		profile.OnVcApprovalRejectionNotification = req.onVcApprovalRejectionNotification
+	}
+	if req.privateUserEmails != nil {
+		profile.PrivateUserEmails = req.privateUserEmails
+	}
+	if req.ports != nil {
+		profile.Ports = req.ports
+	}
+	return profile
+}
+
+func (c RestClient) getL2ServiceProfileAPI(uuid string) (*api.L2ServiceProfile, error) {
+	path := newAPIPath("/ecx/v3/l2/serviceprofiles/{profileUUID}", uuid)
+	respBody := api.L2ServiceProfile{}
+	req := c.R().SetResult(&respBody)
+	if err := c.execute("GetL2ServiceProfile", req, http.MethodGet, path); err != nil {
+		return nil, err
+	}
+	return &respBody, nil
+}
+
+//isL2ServiceProfileModified compares profile's last update dates when available,
+//otherwise whole profiles are compared
+func isL2ServiceProfileModified(baseline api.L2ServiceProfile, latest api.L2ServiceProfile) bool {
+	if baseline.LastUpdatedDate != nil && latest.LastUpdatedDate != nil {
+		return *baseline.LastUpdatedDate != *latest.LastUpdatedDate
+	}
+	return !reflect.DeepEqual(baseline, latest)
+}
+
+func diffL2ServiceProfiles(current L2ServiceProfile, updated L2ServiceProfile) []L2ServiceProfileChange {
+	var changes []L2ServiceProfileChange
+	add := func(field string, from string, to string) {
+		if from != to {
+			changes = append(changes, L2ServiceProfileChange{Field: field, From: from, To: to})
+		}
+	}
+	add("Name", StringValue(current.Name), StringValue(updated.Name))
+	add("Description", StringValue(current.Description), StringValue(updated.Description))
+	add("SpeedBands", formatSpeedBands(current.SpeedBands), formatSpeedBands(updated.SpeedBands))
+	add("OnBandwidthThresholdNotification", strings.Join(current.OnBandwidthThresholdNotification, ", "),
+		strings.Join(updated.OnBandwidthThresholdNotification, ", "))
+	add("OnProfileApprovalRejectNotification", strings.Join(current.OnProfileApprovalRejectNotification, ", "),
+		strings.Join(updated.OnProfileApprovalRejectNotification, ", "))
+	add("OnVcApprovalRejectionNotification", strings.Join(current.OnVcApprovalRejectionNotification, ", "),
+		strings.Join(updated.OnVcApprovalRejectionNotification, ", "))
+	add("PrivateUserEmails", strings.Join(current.PrivateUserEmails, ", "), strings.Join(updated.PrivateUserEmails, ", "))
+	add("Ports", formatProfilePorts(current.Ports), formatProfilePorts(updated.Ports))
+	return changes
+}
+
+func formatSpeedBands(bands []L2ServiceProfileSpeedBand) string {
+	formatted := make([]string, len(bands))
+	for i, band := range bands {
+		if bandwidth, err := speedBandwidth(band.Speed, band.SpeedUnit); err == nil {
+			formatted[i] = bandwidth.String()
+		} else {
+			formatted[i] = strconv.Itoa(IntValue(band.Speed)) + StringValue(band.SpeedUnit)
+		}
+	}
+	return strings.Join(formatted, ", ")
+}
+
+func formatProfilePorts(ports []L2ServiceProfilePort) string {
+	formatted := make([]string, len(ports))
+	for i, port := range ports {
+		formatted[i] = StringValue(port.ID) + " (" + StringValue(port.MetroCode) + ")"
+	}
+	return strings.Join(formatted, ", ")
+}
+
+//nonNil* functions make sure that empty list set in update request is distinguishable
+//from a list that was not set at all
+
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
+func nonNilSpeedBands(bands []L2ServiceProfileSpeedBand) []L2ServiceProfileSpeedBand {
+	if bands == nil {
+		return []L2ServiceProfileSpeedBand{}
+	}
+	return bands
+}
+
+func nonNilPorts(ports []L2ServiceProfilePort) []L2ServiceProfilePort {
+	if ports == nil {
+		return []L2ServiceProfilePort{}
+	}
+	return ports
+}
@@ -0,0 +1,160 @@
+package ecx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/equinix/ecx-go/v2/internal/api"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func readProfileUpdateTestData(t *testing.T) api.L2ServiceProfile {
+	profile := api.L2ServiceProfile{}
+	if err := readJSONData("./test-fixtures/ecx_l2serviceprofile_get_resp.json", &profile); err != nil {
+		assert.Failf(t, "Cannot read test response due to %s", err.Error())
+	}
+	return profile
+}
+
+func registerProfileUpdateResponders(testHc *http.Client, profiles []api.L2ServiceProfile, reqBody *api.L2ServiceProfile) *int {
+	gets := 0
+	puts := 0
+	uuid := StringValue(profiles[0].UUID)
+	httpmock.ActivateNonDefault(testHc)
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ecx/v3/l2/serviceprofiles/%s", baseURL, uuid),
+		func(r *http.Request) (*http.Response, error) {
+			profile := profiles[len(profiles)-1]
+			if gets < len(profiles) {
+				profile = profiles[gets]
+			}
+			gets++
+			resp, _ := httpmock.NewJsonResponse(200, profile)
+			return resp, nil
+		},
+	)
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("%s/ecx/v3/l2/serviceprofiles", baseURL),
+		func(r *http.Request) (*http.Response, error) {
+			puts++
+			if err := json.NewDecoder(r.Body).Decode(reqBody); err != nil {
+				return httpmock.NewStringResponse(400, ""), nil
+			}
+			resp, _ := httpmock.NewJsonResponse(200, api.CreateL2ServiceProfileResponse{UUID: String(uuid)})
+			return resp, nil
+		},
+	)
+	return &puts
+}
+
+func TestL2ServiceProfileUpdateRequest(t *testing.T) {
+	//Given
+	profile := readProfileUpdateTestData(t)
+	reqBody := api.L2ServiceProfile{}
+	testHc := &http.Client{}
+	puts := registerProfileUpdateResponders(testHc, []api.L2ServiceProfile{profile}, &reqBody)
+	defer httpmock.DeactivateAndReset()
+	newName := "newName"
+	newEmails := []string{"jane@example.com"}
+	newBands := []L2ServiceProfileSpeedBand{{Speed: Int(1), SpeedUnit: String("GB")}}
+
+	//When
+	ecxClient := NewClient(context.Background(), baseURL, testHc)
+	req := ecxClient.NewL2ServiceProfileUpdateRequest(StringValue(profile.UUID)).
+		WithName(newName).
+		WithDescription(StringValue(profile.Description)).
+		WithPrivateUserEmails(newEmails).
+		WithSpeedBands(newBands)
+	changes, diffErr := req.Diff()
+	err := req.Execute()
+
+	//Then
+	assert.Nil(t, diffErr, "Diff should not return an error")
+	assert.ElementsMatch(t, []L2ServiceProfileChange{
+		{Field: "Name", From: StringValue(profile.Name), To: newName},
+		{Field: "SpeedBands", From: "200MB, 500MB, 1GB", To: "1GB"},
+		{Field: "PrivateUserEmails", From: strings.Join(profile.PrivateUserEmails, ", "), To: "jane@example.com"},
+	}, changes, "Changes match")
+	assert.Nil(t, err, "Client should not return an error")
+	assert.Equal(t, 1, *puts, "Profile was updated once")
+	assert.Equal(t, profile.UUID, reqBody.UUID, "UUID matches")
+	assert.Equal(t, newName, StringValue(reqBody.Name), "Name matches")
+	assert.Equal(t, profile.Description, reqBody.Description, "Description matches")
+	assert.Equal(t, newEmails, reqBody.PrivateUserEmails, "PrivateUserEmails match")
+	assert.Equal(t, mapSpeedBandsDomainToAPI(newBands), reqBody.SpeedBands, "SpeedBands match")
+	assert.Equal(t, profile.Ports, reqBody.Ports, "Ports are preserved")
+	assert.Equal(t, profile.OnBandwidthThresholdNotification, reqBody.OnBandwidthThresholdNotification, "OnBandwidthThresholdNotification is preserved")
+	assert.Equal(t, profile.APIAvailable, reqBody.APIAvailable, "APIAvailable is preserved")
+	assert.Equal(t, profile.TagType, reqBody.TagType, "TagType is preserved")
+}
+
+func TestL2ServiceProfileUpdateRequest_noChanges(t *testing.T) {
+	//Given
+	profile := readProfileUpdateTestData(t)
+	reqBody := api.L2ServiceProfile{}
+	testHc := &http.Client{}
+	puts := registerProfileUpdateResponders(testHc, []api.L2ServiceProfile{profile}, &reqBody)
+	defer httpmock.DeactivateAndReset()
+
+	//When
+	ecxClient := NewClient(context.Background(), baseURL, testHc)
+	err := ecxClient.NewL2ServiceProfileUpdateRequest(StringValue(profile.UUID)).
+		WithName(StringValue(profile.Name)).
+		Execute()
+
+	//Then
+	assert.Nil(t, err, "Client should not return an error")
+	assert.Equal(t, 0, *puts, "Profile was not updated")
+}
+
+func TestL2ServiceProfileUpdateRequest_preservesFields(t *testing.T) {
+	//Given
+	profile := readProfileUpdateTestData(t)
+	profile.AdditionalInfos = []api.L2SellerProfileAdditionalInfo{
+		{Name: String("account"), DataType: String("STRING"), Mandatory: Bool(true)},
+	}
+	profile.ProfileEncapsulation = String("Dot1q")
+	profile.Metros = []api.L2SellerProfileMetro{{Code: String("SV"), Name: String("Silicon Valley")}}
+	reqBody := api.L2ServiceProfile{}
+	testHc := &http.Client{}
+	registerProfileUpdateResponders(testHc, []api.L2ServiceProfile{profile}, &reqBody)
+	defer httpmock.DeactivateAndReset()
+
+	//When
+	ecxClient := NewClient(context.Background(), baseURL, testHc)
+	err := ecxClient.NewL2ServiceProfileUpdateRequest(StringValue(profile.UUID)).
+		WithName("newName").
+		Execute()
+
+	//Then
+	assert.Nil(t, err, "Client should not return an error")
+	assert.Equal(t, profile.AdditionalInfos, reqBody.AdditionalInfos, "AdditionalInfos are preserved")
+	assert.Equal(t, profile.ProfileEncapsulation, reqBody.ProfileEncapsulation, "Encapsulation is preserved")
+	assert.Empty(t, reqBody.Metros, "Server assigned Metros are not sent")
+	assert.Nil(t, reqBody.OrganizationName, "Server assigned OrganizationName is not sent")
+}
+
+func TestL2ServiceProfileUpdateRequest_conflict(t *testing.T) {
+	//Given
+	profile := readProfileUpdateTestData(t)
+	modified := readProfileUpdateTestData(t)
+	modified.LastUpdatedDate = String("2020-05-16T08:00:00.000Z")
+	reqBody := api.L2ServiceProfile{}
+	testHc := &http.Client{}
+	puts := registerProfileUpdateResponders(testHc, []api.L2ServiceProfile{profile, modified}, &reqBody)
+	defer httpmock.DeactivateAndReset()
+
+	//When
+	ecxClient := NewClient(context.Background(), baseURL, testHc)
+	req := ecxClient.NewL2ServiceProfileUpdateRequest(StringValue(profile.UUID)).WithName("newName")
+	_, diffErr := req.Diff()
+	err := req.Execute()
+
+	//Then
+	assert.Nil(t, diffErr, "Diff should not return an error")
+	assert.IsType(t, L2ServiceProfileModifiedError{}, err, "Error is a modification error")
+	assert.Equal(t, 0, *puts, "Profile was not updated")
+}
@@ -0,0 +1,141 @@
+package ecx
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"sync"
+
+	"github.com/equinix/rest-go"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const defaultPageConcurrency = 4
+
+//SetPageConcurrency sets maximum number of pages fetched concurrently when
+//retrieving paginated collections, i.e. outgoing connections or seller profiles.
+//Concurrency of one fetches pages sequentially
+func (c *RestClient) SetPageConcurrency(concurrency int) *RestClient {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	c.pageConcurrency = concurrency
+	return c
+}
+
+type paginatedPage struct {
+	totalCount int
+	content    []interface{}
+	err        error
+}
+
+//getPaginated retrieves all elements of a paginated collection. First page reveals
+//total number of elements and page size, remaining pages are fetched concurrently and
+//reassembled in page order. Elements that appear on more than one page, i.e. shifted
+//due to collection modifications between requests, are returned once based on a given key
+func (c RestClient) getPaginated(operation string, path apiPath, result interface{}, conf *rest.PagingConfig, key func(item interface{}) string) ([]interface{}, error) {
+	c, span := c.startSpan(operation, trace.SpanKindInternal)
+	content, pages, totalCount, err := c.getAllPages(operation, path, result, conf, key)
+	if c.tracer != nil {
+		span.SetAttributes(TraceAttributePageCount.Int(pages), TraceAttributeTotalCount.Int(totalCount))
+	}
+	endSpan(span, err)
+	return content, err
+}
+
+func (c RestClient) getAllPages(operation string, path apiPath, result interface{}, conf *rest.PagingConfig, key func(item interface{}) string) ([]interface{}, int, int, error) {
+	resultType := reflect.TypeOf(result)
+	if resultType.Kind() != reflect.Ptr {
+		return nil, 0, 0, fmt.Errorf("operation failed, provided result is not a ptr")
+	}
+	first := c.getPage(operation, path, resultType.Elem(), conf, -1)
+	if first.err != nil {
+		return nil, 1, 0, first.err
+	}
+	pageSize := c.PageSize
+	//server may limit page size below requested one, empty first page
+	//does not tell the limit so requested page size is kept
+	if len(first.content) > 0 && len(first.content) < pageSize && len(first.content) < first.totalCount {
+		pageSize = len(first.content)
+	}
+	pages := []paginatedPage{first}
+	if pageSize > 0 && first.totalCount > pageSize {
+		remaining := (first.totalCount - 1) / pageSize
+		pages = append(pages, c.getPages(operation, path, resultType.Elem(), conf, remaining)...)
+	}
+	c.observePages(operation, len(pages))
+	content := make([]interface{}, 0, first.totalCount)
+	seen := make(map[string]struct{}, first.totalCount)
+	for _, page := range pages {
+		if page.err != nil {
+			return nil, len(pages), first.totalCount, page.err
+		}
+		for _, item := range page.content {
+			k := key(item)
+			if _, ok := seen[k]; ok && k != "" {
+				continue
+			}
+			seen[k] = struct{}{}
+			content = append(content, item)
+		}
+	}
+	return content, len(pages), first.totalCount, nil
+}
+
+//getPages fetches given number of pages that follow the first page,
+//with at most pageConcurrency requests in progress
+func (c RestClient) getPages(operation string, path apiPath, resultType reflect.Type, conf *rest.PagingConfig, count int) []paginatedPage {
+	concurrency := c.pageConcurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	pages := make([]paginatedPage, count)
+	sem := make(chan struct{}, concurrency)
+	wg := sync.WaitGroup{}
+	for i := range pages {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			pages[i] = c.getPage(operation, path, resultType, conf, conf.FirstPageNumber+1+i)
+		}(i)
+	}
+	wg.Wait()
+	return pages
+}
+
+//getPage fetches single page of a collection. Page number is omitted
+//for negative pageNumber, so server returns the first page
+func (c RestClient) getPage(operation string, path apiPath, resultType reflect.Type, conf *rest.PagingConfig, pageNumber int) paginatedPage {
+	result := reflect.New(resultType)
+	req := c.R().SetResult(result.Interface()).
+		SetQueryParams(conf.AdditionalParams).
+		SetQueryParam(conf.SizeParamName, strconv.Itoa(c.PageSize))
+	attributes := c.requestSpanAttributes(http.MethodGet, path)
+	if pageNumber >= 0 {
+		req.SetQueryParam(conf.PageParamName, strconv.Itoa(pageNumber))
+		if attributes != nil {
+			attributes = append(attributes, TraceAttributePageNumber.Int(pageNumber))
+		}
+	}
+	if err := c.invoke(operation+" page", operation, attributes, req, http.MethodGet, path); err != nil {
+		return paginatedPage{err: err}
+	}
+	totalValue := reflect.Indirect(result.Elem().FieldByName(conf.TotalCountFieldName))
+	if totalValue.Kind() != reflect.Int {
+		return paginatedPage{err: fmt.Errorf("kind of %s field in %s is %s and not %s", conf.TotalCountFieldName, resultType, totalValue.Kind(), reflect.Int)}
+	}
+	contentValue := reflect.Indirect(result.Elem().FieldByName(conf.ContentFieldName))
+	if contentValue.Kind() != reflect.Slice {
+		return paginatedPage{err: fmt.Errorf("kind of %s field in %s is %s and not %s", conf.ContentFieldName, resultType, contentValue.Kind(), reflect.Slice)}
+	}
+	content := make([]interface{}, contentValue.Len())
+	for i := range content {
+		content[i] = contentValue.Index(i).Interface()
+	}
+	return paginatedPage{totalCount: int(totalValue.Int()), content: content}
+}
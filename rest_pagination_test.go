@@ -0,0 +1,217 @@
+package ecx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/equinix/ecx-go/v2/internal/api"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+//connectionPages simulates paginated outgoing connections listing. Page function
+//returns content of a page with a given number, first page is requested without number
+type connectionPages struct {
+	total    int
+	page     func(pageNumber int) []api.L2ConnectionResponse
+	delay    time.Duration
+	inFlight int32
+	maxIn    int32
+	requests int32
+}
+
+func (p *connectionPages) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	atomic.AddInt32(&p.requests, 1)
+	in := atomic.AddInt32(&p.inFlight, 1)
+	defer atomic.AddInt32(&p.inFlight, -1)
+	for {
+		max := atomic.LoadInt32(&p.maxIn)
+		if in <= max || atomic.CompareAndSwapInt32(&p.maxIn, max, in) {
+			break
+		}
+	}
+	time.Sleep(p.delay)
+	pageNumber := 0
+	if v := r.URL.Query().Get("pageNumber"); v != "" {
+		pageNumber, _ = strconv.Atoi(v)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(api.L2BuyerConnectionsResponse{
+		TotalCount: Int(p.total),
+		PageNumber: Int(pageNumber),
+		Content:    p.page(pageNumber),
+	})
+}
+
+func (p *connectionPages) responder(r *http.Request) (*http.Response, error) {
+	rec := httptest.NewRecorder()
+	p.ServeHTTP(rec, r)
+	return rec.Result(), nil
+}
+
+func testConnections(from, to int) []api.L2ConnectionResponse {
+	conns := make([]api.L2ConnectionResponse, 0, to-from)
+	for i := from; i < to; i++ {
+		conns = append(conns, api.L2ConnectionResponse{UUID: String(fmt.Sprintf("uuid-%04d", i))})
+	}
+	return conns
+}
+
+func pagedTestConnections(total int, pageSize int) func(pageNumber int) []api.L2ConnectionResponse {
+	return func(pageNumber int) []api.L2ConnectionResponse {
+		to := (pageNumber + 1) * pageSize
+		if to > total {
+			to = total
+		}
+		return testConnections(pageNumber*pageSize, to)
+	}
+}
+
+func registerConnectionPages(testHc *http.Client, pages *connectionPages) {
+	httpmock.ActivateNonDefault(testHc)
+	httpmock.RegisterResponder("GET", fmt.Sprintf("=~^%s/ecx/v3/l2/buyer/connections", baseURL), pages.responder)
+}
+
+func TestGetL2OutgoingConnections_parallelPages(t *testing.T) {
+	//Given
+	pages := &connectionPages{total: 23, page: pagedTestConnections(23, 5), delay: 10 * time.Millisecond}
+	testHc := &http.Client{}
+	registerConnectionPages(testHc, pages)
+	defer httpmock.DeactivateAndReset()
+	ecxClient := NewClient(context.Background(), baseURL, testHc).SetPageConcurrency(3)
+	ecxClient.SetPageSize(5)
+
+	//When
+	conns, err := ecxClient.GetL2OutgoingConnections(nil)
+
+	//Then
+	assert.Nil(t, err, "Client should not return an error")
+	assert.Equal(t, 23, len(conns), "Number of connections matches")
+	for i := range conns {
+		assert.Equal(t, fmt.Sprintf("uuid-%04d", i), StringValue(conns[i].UUID), "Connections are in page order")
+	}
+	assert.Equal(t, int32(5), pages.requests, "Each page is fetched once")
+	assert.True(t, pages.maxIn > 1, "Pages are fetched concurrently")
+	assert.True(t, pages.maxIn <= 3, "Concurrency is bounded")
+}
+
+func TestGetL2OutgoingConnections_shiftedItems(t *testing.T) {
+	//Given
+	pages := &connectionPages{total: 6, page: func(pageNumber int) []api.L2ConnectionResponse {
+		//connection deleted after first page was fetched shifts uuid-0003 to the first page
+		if pageNumber == 0 {
+			return testConnections(1, 4)
+		}
+		return testConnections(3, 6)
+	}}
+	testHc := &http.Client{}
+	registerConnectionPages(testHc, pages)
+	defer httpmock.DeactivateAndReset()
+	ecxClient := NewClient(context.Background(), baseURL, testHc)
+	ecxClient.SetPageSize(3)
+
+	//When
+	conns, err := ecxClient.GetL2OutgoingConnections(nil)
+
+	//Then
+	assert.Nil(t, err, "Client should not return an error")
+	uuids := make([]string, len(conns))
+	for i := range conns {
+		uuids[i] = StringValue(conns[i].UUID)
+	}
+	assert.Equal(t, []string{"uuid-0001", "uuid-0002", "uuid-0003", "uuid-0004", "uuid-0005"}, uuids, "Shifted connection is returned once")
+}
+
+func TestGetL2OutgoingConnections_serverPageSizeLimit(t *testing.T) {
+	//Given
+	pages := &connectionPages{total: 7, page: pagedTestConnections(7, 2)}
+	testHc := &http.Client{}
+	registerConnectionPages(testHc, pages)
+	defer httpmock.DeactivateAndReset()
+	ecxClient := NewClient(context.Background(), baseURL, testHc)
+	ecxClient.SetPageSize(10)
+
+	//When
+	conns, err := ecxClient.GetL2OutgoingConnections(nil)
+
+	//Then
+	assert.Nil(t, err, "Client should not return an error")
+	assert.Equal(t, 7, len(conns), "All connections are fetched with server's page size")
+	assert.Equal(t, int32(4), pages.requests, "Number of requests matches")
+}
+
+func TestGetL2OutgoingConnections_emptyFirstPage(t *testing.T) {
+	//Given
+	paged := pagedTestConnections(5, 2)
+	pages := &connectionPages{total: 5, page: func(pageNumber int) []api.L2ConnectionResponse {
+		if pageNumber == 0 {
+			return nil
+		}
+		return paged(pageNumber)
+	}}
+	testHc := &http.Client{}
+	registerConnectionPages(testHc, pages)
+	defer httpmock.DeactivateAndReset()
+	ecxClient := NewClient(context.Background(), baseURL, testHc)
+	ecxClient.SetPageSize(2)
+
+	//When
+	conns, err := ecxClient.GetL2OutgoingConnections(nil)
+
+	//Then
+	assert.Nil(t, err, "Client should not return an error")
+	assert.Equal(t, 3, len(conns), "Connections from following pages are fetched")
+	assert.Equal(t, int32(3), pages.requests, "Number of requests matches")
+}
+
+func TestGetL2OutgoingConnections_pageError(t *testing.T) {
+	//Given
+	pages := &connectionPages{total: 9, page: pagedTestConnections(9, 3)}
+	testHc := &http.Client{}
+	httpmock.ActivateNonDefault(testHc)
+	httpmock.RegisterResponder("GET", fmt.Sprintf("=~^%s/ecx/v3/l2/buyer/connections", baseURL),
+		func(r *http.Request) (*http.Response, error) {
+			if r.URL.Query().Get("pageNumber") == "2" {
+				return httpmock.NewStringResponse(500, ""), nil
+			}
+			return pages.responder(r)
+		},
+	)
+	defer httpmock.DeactivateAndReset()
+	ecxClient := NewClient(context.Background(), baseURL, testHc)
+	ecxClient.SetPageSize(3)
+
+	//When
+	conns, err := ecxClient.GetL2OutgoingConnections(nil)
+
+	//Then
+	assert.NotNil(t, err, "Client should return an error")
+	assert.Nil(t, conns, "Client should not return connections")
+}
+
+//BenchmarkGetL2OutgoingConnections fetches 1000 connections in pages of 50 from
+//local server that simulates API latency
+func BenchmarkGetL2OutgoingConnections(b *testing.B) {
+	for _, concurrency := range []int{1, 4, 8} {
+		b.Run(fmt.Sprintf("concurrency-%d", concurrency), func(b *testing.B) {
+			pages := &connectionPages{total: 1000, page: pagedTestConnections(1000, 50), delay: 5 * time.Millisecond}
+			server := httptest.NewServer(pages)
+			defer server.Close()
+			ecxClient := NewClient(context.Background(), server.URL, server.Client()).SetPageConcurrency(concurrency)
+			ecxClient.SetPageSize(50)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := ecxClient.GetL2OutgoingConnections(nil); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -3,16 +3,18 @@ package ecx
 import (
 	"fmt"
 	"net/http"
+	"sort"
+	"strings"
 
 	"github.com/equinix/ecx-go/v2/internal/api"
 )
 
 //GetUserPorts operation retrieves Equinix Fabric user ports
 func (c RestClient) GetUserPorts() ([]Port, error) {
-	path := "/ecx/v3/port/userport"
+	path := newAPIPath("/ecx/v3/port/userport")
 	respBody := []api.Port{}
 	req := c.R().SetResult(&respBody)
-	if err := c.Execute(req, http.MethodGet, path); err != nil {
+	if err := c.execute("GetUserPorts", req, http.MethodGet, path); err != nil {
 		return nil, err
 	}
 	mapped := make([]Port, len(respBody))
@@ -22,17 +24,239 @@ func (c RestClient) GetUserPorts() ([]Port, error) {
 	return mapped, nil
 }
 
+//GetUserPort operation retrieves Equinix Fabric user port with a given UUID
+func (c RestClient) GetUserPort(uuid string) (*Port, error) {
+	path := newAPIPath("/ecx/v3/port/userport/{portUUID}", uuid)
+	respBody := api.Port{}
+	req := c.R().SetResult(&respBody)
+	if err := c.execute("GetUserPort", req, http.MethodGet, path); err != nil {
+		return nil, err
+	}
+	mapped := mapPortAPIToDomain(respBody)
+	return &mapped, nil
+}
+
+//GetPortVlanAvailability operation determines VLAN tags that are in use on a user port
+//with a given UUID. Usage is derived from existing, not removed connections
+//originating from or terminating on the port
+func (c RestClient) GetPortVlanAvailability(uuid string) (*PortVlanAvailability, error) {
+	port, err := c.GetUserPort(uuid)
+	if err != nil {
+		return nil, err
+	}
+	conns, err := c.getPortConnections(uuid)
+	if err != nil {
+		return nil, err
+	}
+	availability := &PortVlanAvailability{
+		PortUUID:      port.UUID,
+		Encapsulation: port.Encapsulation,
+	}
+	for _, conn := range conns {
+		if StringValue(conn.PortUUID) == uuid && conn.VlanSTag != nil {
+			availability.UsedVlans = append(availability.UsedVlans, PortVlan{
+				ConnectionUUID: conn.UUID,
+				STag:           conn.VlanSTag,
+				CTag:           conn.VlanCTag,
+			})
+		}
+		if StringValue(conn.ZSidePortUUID) == uuid && conn.ZSideVlanSTag != nil {
+			availability.UsedVlans = append(availability.UsedVlans, PortVlan{
+				ConnectionUUID: conn.UUID,
+				STag:           conn.ZSideVlanSTag,
+				CTag:           conn.ZSideVlanCTag,
+			})
+		}
+	}
+	return availability, nil
+}
+
+//GetPortCapacity operation computes remaining capacity of a user port with a given UUID
+//by subtracting speeds of existing, not removed connections originating from or terminating
+//on the port from port's total bandwidth
+func (c RestClient) GetPortCapacity(uuid string) (*PortCapacity, error) {
+	port, err := c.GetUserPort(uuid)
+	if err != nil {
+		return nil, err
+	}
+	conns, err := c.getPortConnections(uuid)
+	if err != nil {
+		return nil, err
+	}
+	total, err := speedBandwidth(port.Speed, port.SpeedUnit)
+	if err != nil {
+		return nil, fmt.Errorf("cannot determine bandwidth of port %q: %s", uuid, err)
+	}
+	var allocated Bandwidth
+	connUUIDs := make([]string, 0, len(conns))
+	for _, conn := range conns {
+		speed, err := speedBandwidth(conn.Speed, conn.SpeedUnit)
+		if err != nil {
+			return nil, fmt.Errorf("cannot determine speed of connection %q: %s", StringValue(conn.UUID), err)
+		}
+		allocated += speed
+		connUUIDs = append(connUUIDs, StringValue(conn.UUID))
+	}
+	capacity := &PortCapacity{
+		PortUUID:        port.UUID,
+		Speed:           Int(int(total / Megabit)),
+		AllocatedSpeed:  Int(int(allocated / Megabit)),
+		RemainingSpeed:  Int(int((total - allocated) / Megabit)),
+		SpeedUnit:       String(SpeedUnitMB),
+		ConnectionUUIDs: connUUIDs,
+	}
+	return capacity, nil
+}
+
+//IsSTagAvailable checks if a given S-Tag is not used by any connection on the port
+func (a PortVlanAvailability) IsSTagAvailable(sTag int) bool {
+	if sTag < VlanTagMin || sTag > VlanTagMax {
+		return false
+	}
+	for _, vlan := range a.UsedVlans {
+		if IntValue(vlan.STag) == sTag {
+			return false
+		}
+	}
+	return true
+}
+
+//IsAvailable checks if a given S-Tag and C-Tag pair is not used by any connection
+//on the port. Connection that uses S-Tag without C-Tag occupies whole S-Tag
+func (a PortVlanAvailability) IsAvailable(sTag int, cTag int) bool {
+	if sTag < VlanTagMin || sTag > VlanTagMax || cTag < VlanTagMin || cTag > VlanTagMax {
+		return false
+	}
+	for _, vlan := range a.UsedVlans {
+		if IntValue(vlan.STag) != sTag {
+			continue
+		}
+		if vlan.CTag == nil || IntValue(vlan.CTag) == cTag {
+			return false
+		}
+	}
+	return true
+}
+
+//UsedSTags returns sorted list of distinct S-Tags used on the port
+func (a PortVlanAvailability) UsedSTags() []int {
+	seen := make(map[int]struct{})
+	for _, vlan := range a.UsedVlans {
+		if vlan.STag != nil {
+			seen[*vlan.STag] = struct{}{}
+		}
+	}
+	tags := make([]int, 0, len(seen))
+	for tag := range seen {
+		tags = append(tags, tag)
+	}
+	sort.Ints(tags)
+	return tags
+}
+
+//AvailableSTags returns sorted list of S-Tags that are not used on the port
+func (a PortVlanAvailability) AvailableSTags() []int {
+	tags := make([]int, 0, VlanTagMax-VlanTagMin+1)
+	for tag := VlanTagMin; tag <= VlanTagMax; tag++ {
+		if a.IsSTagAvailable(tag) {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+func (c RestClient) getPortConnections(uuid string) ([]L2Connection, error) {
+	conns, err := c.GetL2OutgoingConnections(nil)
+	if err != nil {
+		return nil, err
+	}
+	filtered := make([]L2Connection, 0, len(conns))
+	for _, conn := range conns {
+		if StringValue(conn.PortUUID) != uuid && StringValue(conn.ZSidePortUUID) != uuid {
+			continue
+		}
+		switch ConnectionStatus(StringValue(conn.Status)) {
+		case ConnectionStatusDeprovisioned, ConnectionStatusDeleted, ConnectionStatusRejected:
+			continue
+		}
+		filtered = append(filtered, conn)
+	}
+	return filtered, nil
+}
+
+//RedundancyPartner returns port from a given list that belongs to the same
+//redundancy group but has different priority, or nil if there is no such port
+func (p Port) RedundancyPartner(ports []Port) *Port {
+	if StringValue(p.RedundancyGroup) == "" {
+		return nil
+	}
+	for i := range ports {
+		if StringValue(ports[i].UUID) == StringValue(p.UUID) {
+			continue
+		}
+		if StringValue(ports[i].RedundancyGroup) == StringValue(p.RedundancyGroup) &&
+			!strings.EqualFold(StringValue(ports[i].Priority), StringValue(p.Priority)) {
+			return &ports[i]
+		}
+	}
+	return nil
+}
+
 func mapPortAPIToDomain(apiPort api.Port) Port {
-	return Port{
-		UUID:          apiPort.UUID,
-		Name:          apiPort.Name,
-		Region:        apiPort.Region,
-		IBX:           apiPort.IBX,
-		MetroCode:     apiPort.MetroCode,
-		Priority:      apiPort.DevicePriority,
-		Encapsulation: apiPort.Encapsulation,
-		Buyout:        apiPort.Buyout,
-		Bandwidth:     String(fmt.Sprintf("%d", Int64Value(apiPort.TotalBandwidth))),
-		Status:        apiPort.ProvisionStatus,
+	port := Port{
+		UUID:              apiPort.UUID,
+		Name:              apiPort.Name,
+		Region:            apiPort.Region,
+		IBX:               apiPort.IBX,
+		MetroCode:         apiPort.MetroCode,
+		Priority:          apiPort.DevicePriority,
+		Encapsulation:     apiPort.Encapsulation,
+		Buyout:            apiPort.Buyout,
+		Status:            apiPort.ProvisionStatus,
+		Device:            apiPort.Device,
+		RedundancyGroup:   apiPort.DeviceGroup,
+		IsLAG:             apiPort.Lag,
+		Layer3Enabled:     apiPort.Layer3Enabled,
+		SharedPortType:    apiPort.SharedPortType,
+		SharedPortProduct: apiPort.SharedPortProduct,
+		PhysicalPorts:     mapPhysicalPortsAPIToDomain(apiPort.UserPorts),
+	}
+	if apiPort.TotalBandwidth != nil {
+		port.Bandwidth = String(fmt.Sprintf("%d", *apiPort.TotalBandwidth))
+		port.Speed, port.SpeedUnit = mapBandwidthAPIToSpeed(*apiPort.TotalBandwidth)
 	}
+	for _, physical := range port.PhysicalPorts {
+		if physical.Status == nil {
+			continue
+		}
+		if port.OperationalStatus == nil || StringValue(port.OperationalStatus) == ConnectionStatusProvisioned {
+			port.OperationalStatus = physical.Status
+		}
+	}
+	return port
+}
+
+func mapPhysicalPortsAPIToDomain(apiPorts []api.PhysicalPort) []PhysicalPort {
+	transformed := make([]PhysicalPort, len(apiPorts))
+	for i := range apiPorts {
+		transformed[i] = PhysicalPort{
+			CrossConnectID: apiPorts[i].CrossConnectID,
+			CabinetNumber:  apiPorts[i].CabinetNumber,
+			CageNumber:     apiPorts[i].CageNumber,
+			PatchPanelName: apiPorts[i].PatchPanelName,
+			TagProtocolID:  apiPorts[i].TagProtocolID,
+			Status:         apiPorts[i].PortProvisionStatus,
+		}
+		if apiPorts[i].Bandwidth != nil {
+			transformed[i].Speed, transformed[i].SpeedUnit = mapBandwidthAPIToSpeed(*apiPorts[i].Bandwidth)
+		}
+	}
+	return transformed
+}
+
+//mapBandwidthAPIToSpeed converts bandwidth in bits per second to speed
+//expressed in the largest unit that gives whole number, down to kilobits
+func mapBandwidthAPIToSpeed(bandwidth int64) (*int, *string) {
+	speed, unit := Bandwidth(bandwidth).Speed()
+	return Int(speed), String(unit)
 }
@@ -49,8 +49,233 @@ func verifyPort(t *testing.T, port Port, apiPort api.Port) {
 	assert.Equal(t, apiPort.Encapsulation, port.Encapsulation, "Encapsulation matches")
 	assert.Equal(t, apiPort.Buyout, port.Buyout, "Buyout matches")
 	assert.Equal(t, apiPort.ProvisionStatus, port.Status, "ProvisionStatus matches")
-	convBandwidth, err := strconv.ParseInt(StringValue(port.Bandwidth), 10, 64)
-	assert.Nil(t, err, "Bandwidth string should be convertable to int64")
-	assert.Equal(t, Int64Value(apiPort.TotalBandwidth), convBandwidth, "TotalBandwidth matches")
+	if apiPort.TotalBandwidth != nil {
+		convBandwidth, err := strconv.ParseInt(StringValue(port.Bandwidth), 10, 64)
+		assert.Nil(t, err, "Bandwidth string should be convertable to int64")
+		assert.Equal(t, Int64Value(apiPort.TotalBandwidth), convBandwidth, "TotalBandwidth matches")
+		speedBandwidth, err := NewBandwidth(IntValue(port.Speed), StringValue(port.SpeedUnit))
+		assert.Nil(t, err, "Speed and SpeedUnit should be convertable to bandwidth")
+		assert.Equal(t, Bandwidth(Int64Value(apiPort.TotalBandwidth)), speedBandwidth, "Speed and SpeedUnit match TotalBandwidth")
+	} else {
+		assert.Nil(t, port.Bandwidth, "Bandwidth is nil")
+		assert.Nil(t, port.Speed, "Speed is nil")
+	}
+	assert.Equal(t, apiPort.Device, port.Device, "Device matches")
+	assert.Equal(t, apiPort.DeviceGroup, port.RedundancyGroup, "DeviceGroup matches")
+	assert.Equal(t, apiPort.Lag, port.IsLAG, "Lag matches")
+	assert.Equal(t, apiPort.Layer3Enabled, port.Layer3Enabled, "Layer3Enabled matches")
+	assert.Equal(t, apiPort.SharedPortType, port.SharedPortType, "SharedPortType matches")
+	assert.Equal(t, apiPort.SharedPortProduct, port.SharedPortProduct, "SharedPortProduct matches")
+	assert.Equal(t, len(apiPort.UserPorts), len(port.PhysicalPorts), "Number of physical ports matches")
+	for i := range apiPort.UserPorts {
+		assert.Equal(t, apiPort.UserPorts[i].CrossConnectID, port.PhysicalPorts[i].CrossConnectID, "CrossConnectID matches")
+		assert.Equal(t, apiPort.UserPorts[i].CabinetNumber, port.PhysicalPorts[i].CabinetNumber, "CabinetNumber matches")
+		assert.Equal(t, apiPort.UserPorts[i].CageNumber, port.PhysicalPorts[i].CageNumber, "CageNumber matches")
+		assert.Equal(t, apiPort.UserPorts[i].PatchPanelName, port.PhysicalPorts[i].PatchPanelName, "PatchPanelName matches")
+		assert.Equal(t, apiPort.UserPorts[i].TagProtocolID, port.PhysicalPorts[i].TagProtocolID, "TagProtocolID matches")
+		assert.Equal(t, apiPort.UserPorts[i].PortProvisionStatus, port.PhysicalPorts[i].Status, "PortProvisionStatus matches")
+	}
+	var operationalStatus *string
+	for i := range apiPort.UserPorts {
+		if operationalStatus == nil || StringValue(operationalStatus) == ConnectionStatusProvisioned {
+			operationalStatus = apiPort.UserPorts[i].PortProvisionStatus
+		}
+	}
+	assert.Equal(t, operationalStatus, port.OperationalStatus, "OperationalStatus matches")
+}
+
+func TestMapPortAPIToDomain_missingValues(t *testing.T) {
+	//given
+	apiPort := api.Port{UUID: String("portUUID")}
+	//when
+	port := mapPortAPIToDomain(apiPort)
+	//then
+	assert.Nil(t, port.Bandwidth, "Missing bandwidth is mapped to nil")
+	assert.Nil(t, port.Speed, "Missing speed is mapped to nil")
+	assert.Nil(t, port.SpeedUnit, "Missing speed unit is mapped to nil")
+	assert.Nil(t, port.OperationalStatus, "Missing operational status is mapped to nil")
+	assert.Empty(t, port.PhysicalPorts, "Missing physical ports are mapped to empty list")
+}
+
+func TestMapPortAPIToDomain_lag(t *testing.T) {
+	//given
+	apiPort := api.Port{
+		UUID:           String("portUUID"),
+		Lag:            Bool(true),
+		TotalBandwidth: Int64(1500000000),
+		UserPorts: []api.PhysicalPort{
+			{Bandwidth: Int64(1000000000), PortProvisionStatus: String("PROVISIONED")},
+			{Bandwidth: Int64(500000000), PortProvisionStatus: String("PROVISIONING")},
+			{Bandwidth: Int64(0), PortProvisionStatus: String("PROVISIONED")},
+			{Bandwidth: Int64(512000), PortProvisionStatus: String("PROVISIONED")},
+		},
+	}
+	//when
+	port := mapPortAPIToDomain(apiPort)
+	//then
+	assert.Equal(t, 1500, IntValue(port.Speed), "Speed is expressed in megabits")
+	assert.Equal(t, "MB", StringValue(port.SpeedUnit), "SpeedUnit matches")
+	assert.Equal(t, 1, IntValue(port.PhysicalPorts[0].Speed), "Physical port speed matches")
+	assert.Equal(t, "GB", StringValue(port.PhysicalPorts[0].SpeedUnit), "Physical port speed unit matches")
+	assert.Equal(t, 512, IntValue(port.PhysicalPorts[3].Speed), "Sub-megabit speed is expressed in kilobits")
+	assert.Equal(t, "KB", StringValue(port.PhysicalPorts[3].SpeedUnit), "Sub-megabit speed unit matches")
+	assert.Equal(t, "PROVISIONING", StringValue(port.OperationalStatus), "Operational status reflects member that is not provisioned")
+}
+
+func TestPort_RedundancyPartner(t *testing.T) {
+	//given
+	ports := []Port{
+		{UUID: String("pri"), RedundancyGroup: String("group"), Priority: String("Primary")},
+		{UUID: String("other"), RedundancyGroup: String("otherGroup"), Priority: String("Secondary")},
+		{UUID: String("sec"), RedundancyGroup: String("group"), Priority: String("Secondary")},
+		{UUID: String("single"), Priority: String("Primary")},
+	}
+	//when
+	partner := ports[0].RedundancyPartner(ports)
+	noPartner := ports[3].RedundancyPartner(ports)
+	//then
+	assert.NotNil(t, partner, "Redundancy partner is found")
+	assert.Equal(t, "sec", StringValue(partner.UUID), "Redundancy partner UUID matches")
+	assert.Nil(t, noPartner, "Port without redundancy group has no partner")
+}
+
+func TestGetUserPortByUUID(t *testing.T) {
+	//Given
+	respBody := api.Port{}
+	if err := readJSONData("./test-fixtures/ecx_port_get.json", &respBody); err != nil {
+		assert.Failf(t, "Cannot read test response due to %s", err.Error())
+	}
+	portID := StringValue(respBody.UUID)
+	testHc := &http.Client{}
+	httpmock.ActivateNonDefault(testHc)
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ecx/v3/port/userport/%s", baseURL, portID),
+		func(r *http.Request) (*http.Response, error) {
+			resp, _ := httpmock.NewJsonResponse(200, respBody)
+			return resp, nil
+		},
+	)
+	defer httpmock.DeactivateAndReset()
+
+	//When
+	ecxClient := NewClient(context.Background(), baseURL, testHc)
+	port, err := ecxClient.GetUserPort(portID)
+
+	//Then
+	assert.Nil(t, err, "Client should not return an error")
+	assert.NotNil(t, port, "Client should return a response")
+	verifyPort(t, *port, respBody)
+}
+
+func TestGetPortVlanAvailability(t *testing.T) {
+	//Given
+	portRespBody, connsRespBody := readPortConnectionsTestData(t)
+	portID := StringValue(portRespBody.UUID)
+	testHc := &http.Client{}
+	registerPortConnectionsResponders(testHc, portRespBody, connsRespBody)
+	defer httpmock.DeactivateAndReset()
+
+	//When
+	ecxClient := NewClient(context.Background(), baseURL, testHc)
+	ecxClient.SetPageSize(IntValue(connsRespBody.PageSize))
+	availability, err := ecxClient.GetPortVlanAvailability(portID)
+
+	//Then
+	assert.Nil(t, err, "Client should not return an error")
+	assert.NotNil(t, availability, "Client should return a response")
+	assert.Equal(t, portRespBody.Encapsulation, availability.Encapsulation, "Encapsulation matches")
+	assert.Equal(t, []int{130, 140}, availability.UsedSTags(), "Used S-Tags match")
+	assert.False(t, availability.IsSTagAvailable(140), "S-Tag used by connection is not available")
+	assert.True(t, availability.IsSTagAvailable(141), "Unused S-Tag is available")
+	assert.False(t, availability.IsSTagAvailable(1), "S-Tag out of range is not available")
+	assert.False(t, availability.IsAvailable(130, 200), "C-Tag under S-Tag used without C-Tag is not available")
+	assert.Equal(t, 4093-2, len(availability.AvailableSTags()), "Number of available S-Tags matches")
+}
+
+func TestGetPortCapacity(t *testing.T) {
+	//Given
+	portRespBody, connsRespBody := readPortConnectionsTestData(t)
+	portID := StringValue(portRespBody.UUID)
+	testHc := &http.Client{}
+	registerPortConnectionsResponders(testHc, portRespBody, connsRespBody)
+	defer httpmock.DeactivateAndReset()
+
+	//When
+	ecxClient := NewClient(context.Background(), baseURL, testHc)
+	ecxClient.SetPageSize(IntValue(connsRespBody.PageSize))
+	capacity, err := ecxClient.GetPortCapacity(portID)
+
+	//Then
+	assert.Nil(t, err, "Client should not return an error")
+	assert.NotNil(t, capacity, "Client should return a response")
+	assert.Equal(t, 10000, IntValue(capacity.Speed), "Total speed matches")
+	assert.Equal(t, 100, IntValue(capacity.AllocatedSpeed), "Allocated speed matches")
+	assert.Equal(t, 9900, IntValue(capacity.RemainingSpeed), "Remaining speed matches")
+	assert.Equal(t, "MB", StringValue(capacity.SpeedUnit), "Speed unit matches")
+	assert.Equal(t, 2, len(capacity.ConnectionUUIDs), "Number of connections matches")
+}
+
+func TestGetPortCapacity_zSide(t *testing.T) {
+	//Given
+	portRespBody, connsRespBody := readPortConnectionsTestData(t)
+	portID := StringValue(portRespBody.UUID)
+	connsRespBody.Content[1].ZSidePortUUID = String(portID)
+	testHc := &http.Client{}
+	registerPortConnectionsResponders(testHc, portRespBody, connsRespBody)
+	defer httpmock.DeactivateAndReset()
+
+	//When
+	ecxClient := NewClient(context.Background(), baseURL, testHc)
+	ecxClient.SetPageSize(IntValue(connsRespBody.PageSize))
+	capacity, err := ecxClient.GetPortCapacity(portID)
+
+	//Then
+	assert.Nil(t, err, "Client should not return an error")
+	assert.Equal(t, 150, IntValue(capacity.AllocatedSpeed), "Allocated speed includes terminating connection")
+	assert.Equal(t, 9850, IntValue(capacity.RemainingSpeed), "Remaining speed matches")
+	assert.Equal(t, 3, len(capacity.ConnectionUUIDs), "Number of connections matches")
+}
+
+func TestPortVlanAvailability_IsAvailable(t *testing.T) {
+	//given
+	availability := PortVlanAvailability{
+		UsedVlans: []PortVlan{
+			{STag: Int(100), CTag: Int(200)},
+			{STag: Int(100), CTag: Int(201)},
+			{STag: Int(300)},
+		},
+	}
+	//then
+	assert.False(t, availability.IsAvailable(100, 200), "Used S-Tag and C-Tag pair is not available")
+	assert.True(t, availability.IsAvailable(100, 202), "Unused C-Tag under used S-Tag is available")
+	assert.False(t, availability.IsAvailable(300, 202), "C-Tag under S-Tag used without C-Tag is not available")
+	assert.True(t, availability.IsAvailable(400, 202), "Unused S-Tag and C-Tag pair is available")
+	assert.False(t, availability.IsAvailable(400, 4095), "C-Tag out of range is not available")
+}
+
+func readPortConnectionsTestData(t *testing.T) (api.Port, api.L2BuyerConnectionsResponse) {
+	portRespBody := api.Port{}
+	if err := readJSONData("./test-fixtures/ecx_port_get.json", &portRespBody); err != nil {
+		assert.Failf(t, "Cannot read test response due to %s", err.Error())
+	}
+	connsRespBody := api.L2BuyerConnectionsResponse{}
+	if err := readJSONData("./test-fixtures/ecx_l2connections_get_resp.json", &connsRespBody); err != nil {
+		assert.Failf(t, "Cannot read test response due to %s", err.Error())
+	}
+	return portRespBody, connsRespBody
+}
 
+func registerPortConnectionsResponders(testHc *http.Client, port api.Port, conns api.L2BuyerConnectionsResponse) {
+	httpmock.ActivateNonDefault(testHc)
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ecx/v3/port/userport/%s", baseURL, StringValue(port.UUID)),
+		func(r *http.Request) (*http.Response, error) {
+			resp, _ := httpmock.NewJsonResponse(200, port)
+			return resp, nil
+		},
+	)
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ecx/v3/l2/buyer/connections?pageSize=%d", baseURL, IntValue(conns.PageSize)),
+		func(r *http.Request) (*http.Response, error) {
+			resp, _ := httpmock.NewJsonResponse(200, conns)
+			return resp, nil
+		},
+	)
 }
@@ -0,0 +1,216 @@
+package ecx
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/equinix/ecx-go/v2/internal/api"
+)
+
+//GetL2ConnectionStats operation retrieves traffic statistics of a layer 2 connection
+//with a given UUID, collected between from and to times with a given metric interval.
+//Utilization of each sample is computed against connection's speed
+func (c RestClient) GetL2ConnectionStats(uuid string, from, to time.Time, interval time.Duration) (*TrafficStatistics, error) {
+	conn, err := c.GetL2Connection(uuid)
+	if err != nil {
+		return nil, err
+	}
+	path := newAPIPath("/ecx/v3/l2/connections/{connectionUUID}/stats", uuid)
+	stats, err := c.getTrafficStatistics("GetL2ConnectionStats", path, from, to, interval)
+	if err != nil {
+		return nil, err
+	}
+	stats.UUID = conn.UUID
+	stats.Speed = conn.Speed
+	stats.SpeedUnit = conn.SpeedUnit
+	stats.computeUtilization()
+	return stats, nil
+}
+
+//GetPortStats operation retrieves traffic statistics of a user port with a given UUID,
+//collected between from and to times with a given metric interval.
+//Utilization of each sample is computed against port's total bandwidth
+func (c RestClient) GetPortStats(uuid string, from, to time.Time, interval time.Duration) (*TrafficStatistics, error) {
+	port, err := c.GetUserPort(uuid)
+	if err != nil {
+		return nil, err
+	}
+	path := newAPIPath("/ecx/v3/port/userport/{portUUID}/stats", uuid)
+	stats, err := c.getTrafficStatistics("GetPortStats", path, from, to, interval)
+	if err != nil {
+		return nil, err
+	}
+	stats.UUID = port.UUID
+	stats.Speed = port.Speed
+	stats.SpeedUnit = port.SpeedUnit
+	stats.computeUtilization()
+	return stats, nil
+}
+
+//Peak returns the highest bandwidth, in megabits per second, observed
+//in a given traffic direction
+func (s TrafficStatistics) Peak(direction string) float64 {
+	values := s.bandwidthValues(direction)
+	if len(values) == 0 {
+		return 0
+	}
+	return values[len(values)-1]
+}
+
+//P95 returns 95th percentile of bandwidth, in megabits per second,
+//observed in a given traffic direction
+func (s TrafficStatistics) P95(direction string) float64 {
+	return s.Percentile(direction, 95)
+}
+
+//Percentile returns given percentile (0-100) of bandwidth, in megabits per second,
+//observed in a given traffic direction. Nearest-rank method is used
+func (s TrafficStatistics) Percentile(direction string, percentile float64) float64 {
+	values := s.bandwidthValues(direction)
+	if len(values) == 0 {
+		return 0
+	}
+	if percentile <= 0 {
+		return values[0]
+	}
+	rank := int(math.Ceil(percentile / 100 * float64(len(values))))
+	if rank > len(values) {
+		rank = len(values)
+	}
+	return values[rank-1]
+}
+
+//Utilization returns percentage of the capacity that a given bandwidth,
+//in megabits per second, represents. Nil is returned when capacity is unknown
+func (s TrafficStatistics) Utilization(bandwidth float64) *float64 {
+	capacity, err := speedBandwidth(s.Speed, s.SpeedUnit)
+	if err != nil || capacity <= 0 {
+		return nil
+	}
+	return Float64(bandwidth / capacity.Megabits() * 100)
+}
+
+func (s TrafficStatistics) bandwidthValues(direction string) []float64 {
+	values := make([]float64, 0, len(s.Samples))
+	for _, sample := range s.Samples {
+		v := sample.InboundBandwidth
+		if direction == TrafficDirectionOutbound {
+			v = sample.OutboundBandwidth
+		}
+		if v != nil {
+			values = append(values, *v)
+		}
+	}
+	sort.Float64s(values)
+	return values
+}
+
+func (s *TrafficStatistics) computeUtilization() {
+	for i := range s.Samples {
+		if s.Samples[i].InboundBandwidth != nil {
+			s.Samples[i].InboundUtilization = s.Utilization(*s.Samples[i].InboundBandwidth)
+		}
+		if s.Samples[i].OutboundBandwidth != nil {
+			s.Samples[i].OutboundUtilization = s.Utilization(*s.Samples[i].OutboundBandwidth)
+		}
+	}
+}
+
+func (c RestClient) getTrafficStatistics(operation string, path apiPath, from, to time.Time, interval time.Duration) (*TrafficStatistics, error) {
+	respBody := api.TrafficStatisticsResponse{}
+	req := c.R().
+		SetQueryParam("startDateTime", from.UTC().Format(time.RFC3339)).
+		SetQueryParam("endDateTime", to.UTC().Format(time.RFC3339)).
+		SetQueryParam("metricInterval", formatMetricInterval(interval)).
+		SetResult(&respBody)
+	if err := c.execute(operation, req, http.MethodGet, path); err != nil {
+		return nil, err
+	}
+	return mapTrafficStatisticsAPIToDomain(respBody)
+}
+
+func mapTrafficStatisticsAPIToDomain(apiStats api.TrafficStatisticsResponse) (*TrafficStatistics, error) {
+	unit, err := parseTrafficUnit(apiStats.Unit)
+	if err != nil {
+		return nil, err
+	}
+	return &TrafficStatistics{
+		StartDateTime: parseDateTime(apiStats.StartDateTime),
+		EndDateTime:   parseDateTime(apiStats.EndDateTime),
+		Interval:      parseMetricInterval(apiStats.MetricInterval),
+		Samples:       mapTrafficMetricsAPIToDomain(apiStats.Metrics, unit),
+	}, nil
+}
+
+//mapTrafficMetricsAPIToDomain maps metrics with bandwidth expressed in a given unit
+//to samples with bandwidth expressed in megabits per second
+func mapTrafficMetricsAPIToDomain(apiMetrics []api.TrafficMetric, unit Bandwidth) []TrafficSample {
+	transformed := make([]TrafficSample, len(apiMetrics))
+	for i := range apiMetrics {
+		transformed[i] = TrafficSample{
+			IntervalEndDateTime:    parseDateTime(apiMetrics[i].IntervalEndDateTime),
+			InboundBandwidth:       convertToMegabits(apiMetrics[i].InboundBandwidth, unit),
+			OutboundBandwidth:      convertToMegabits(apiMetrics[i].OutboundBandwidth, unit),
+			InboundPacketsDropped:  apiMetrics[i].InboundPacketsDropped,
+			OutboundPacketsDropped: apiMetrics[i].OutboundPacketsDropped,
+		}
+	}
+	return transformed
+}
+
+//parseTrafficUnit parses unit of traffic statistics bandwidth values.
+//Megabits per second are assumed when unit is not returned
+func parseTrafficUnit(unit *string) (Bandwidth, error) {
+	if unit == nil || *unit == "" {
+		return Megabit, nil
+	}
+	if strings.EqualFold(*unit, "bps") {
+		return 1, nil
+	}
+	bandwidth, err := parseSpeedUnit(*unit)
+	if err != nil {
+		return 0, fmt.Errorf("unsupported traffic statistics unit %q", *unit)
+	}
+	return bandwidth, nil
+}
+
+func convertToMegabits(value *float64, unit Bandwidth) *float64 {
+	if value == nil || unit == Megabit {
+		return value
+	}
+	return Float64(*value * float64(unit) / float64(Megabit))
+}
+
+func parseDateTime(value *string) *time.Time {
+	if value == nil {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, *value)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
+
+//formatMetricInterval formats duration as ISO 8601 time duration, i.e. PT5M
+func formatMetricInterval(interval time.Duration) string {
+	if interval%time.Minute == 0 {
+		return fmt.Sprintf("PT%dM", int64(interval/time.Minute))
+	}
+	return fmt.Sprintf("PT%dS", int64(interval/time.Second))
+}
+
+func parseMetricInterval(value *string) *time.Duration {
+	if value == nil || !strings.HasPrefix(*value, "PT") {
+		return nil
+	}
+	d, err := time.ParseDuration(strings.ToLower(strings.TrimPrefix(*value, "PT")))
+	if err != nil {
+		return nil
+	}
+	return &d
+}
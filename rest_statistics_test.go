@@ -0,0 +1,163 @@
+package ecx
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/equinix/ecx-go/v2/internal/api"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	testStatsFrom     = time.Date(2022, 7, 1, 10, 0, 0, 0, time.UTC)
+	testStatsTo       = time.Date(2022, 7, 1, 10, 30, 0, 0, time.UTC)
+	testStatsInterval = 5 * time.Minute
+	testStatsQuery    = url.Values{
+		"startDateTime":  []string{"2022-07-01T10:00:00Z"},
+		"endDateTime":    []string{"2022-07-01T10:30:00Z"},
+		"metricInterval": []string{"PT5M"},
+	}
+)
+
+func TestGetL2ConnectionStats(t *testing.T) {
+	//Given
+	connRespBody := api.L2ConnectionResponse{}
+	if err := readJSONData("./test-fixtures/ecx_l2connection_get_resp.json", &connRespBody); err != nil {
+		assert.Failf(t, "Cannot read test response due to %s", err.Error())
+	}
+	respBody := api.TrafficStatisticsResponse{}
+	if err := readJSONData("./test-fixtures/ecx_l2connection_stats_get_resp.json", &respBody); err != nil {
+		assert.Failf(t, "Cannot read test response due to %s", err.Error())
+	}
+	connID := "connId"
+	testHc := &http.Client{}
+	httpmock.ActivateNonDefault(testHc)
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ecx/v3/l2/connections/%s", baseURL, connID),
+		func(r *http.Request) (*http.Response, error) {
+			resp, _ := httpmock.NewJsonResponse(200, connRespBody)
+			return resp, nil
+		},
+	)
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ecx/v3/l2/connections/%s/stats?%s", baseURL, connID, testStatsQuery.Encode()),
+		func(r *http.Request) (*http.Response, error) {
+			resp, _ := httpmock.NewJsonResponse(200, respBody)
+			return resp, nil
+		},
+	)
+	defer httpmock.DeactivateAndReset()
+
+	//When
+	ecxClient := NewClient(context.Background(), baseURL, testHc)
+	stats, err := ecxClient.GetL2ConnectionStats(connID, testStatsFrom, testStatsTo, testStatsInterval)
+
+	//Then
+	assert.Nil(t, err, "Client should not return an error")
+	assert.NotNil(t, stats, "Client should return a response")
+	assert.Equal(t, connRespBody.UUID, stats.UUID, "UUID matches")
+	assert.Equal(t, connRespBody.Speed, stats.Speed, "Speed matches")
+	assert.Equal(t, connRespBody.SpeedUnit, stats.SpeedUnit, "SpeedUnit matches")
+	assert.Equal(t, testStatsInterval, *stats.Interval, "Interval matches")
+	verifyTrafficStatistics(t, *stats, respBody)
+	assert.Equal(t, 98.0, Float64Value(stats.Samples[4].InboundUtilization), "Inbound utilization is computed against connection speed")
+}
+
+func TestGetPortStats(t *testing.T) {
+	//Given
+	portRespBody := api.Port{}
+	if err := readJSONData("./test-fixtures/ecx_port_get.json", &portRespBody); err != nil {
+		assert.Failf(t, "Cannot read test response due to %s", err.Error())
+	}
+	respBody := api.TrafficStatisticsResponse{}
+	if err := readJSONData("./test-fixtures/ecx_port_stats_get_resp.json", &respBody); err != nil {
+		assert.Failf(t, "Cannot read test response due to %s", err.Error())
+	}
+	portID := StringValue(portRespBody.UUID)
+	testHc := &http.Client{}
+	httpmock.ActivateNonDefault(testHc)
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ecx/v3/port/userport/%s", baseURL, portID),
+		func(r *http.Request) (*http.Response, error) {
+			resp, _ := httpmock.NewJsonResponse(200, portRespBody)
+			return resp, nil
+		},
+	)
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ecx/v3/port/userport/%s/stats?%s", baseURL, portID, testStatsQuery.Encode()),
+		func(r *http.Request) (*http.Response, error) {
+			resp, _ := httpmock.NewJsonResponse(200, respBody)
+			return resp, nil
+		},
+	)
+	defer httpmock.DeactivateAndReset()
+
+	//When
+	ecxClient := NewClient(context.Background(), baseURL, testHc)
+	stats, err := ecxClient.GetPortStats(portID, testStatsFrom, testStatsTo, testStatsInterval)
+
+	//Then
+	assert.Nil(t, err, "Client should not return an error")
+	assert.NotNil(t, stats, "Client should return a response")
+	assert.Equal(t, 10, IntValue(stats.Speed), "Speed matches port bandwidth")
+	assert.Equal(t, "GB", StringValue(stats.SpeedUnit), "SpeedUnit matches")
+	verifyTrafficStatistics(t, *stats, respBody)
+	assert.Equal(t, 40.0, Float64Value(stats.Samples[2].OutboundUtilization), "Outbound utilization is computed against port bandwidth")
+}
+
+func TestTrafficStatistics_PeakAndPercentile(t *testing.T) {
+	//given
+	stats := TrafficStatistics{Speed: Int(100), SpeedUnit: String("MB")}
+	for i := 1; i <= 20; i++ {
+		stats.Samples = append(stats.Samples, TrafficSample{
+			InboundBandwidth:  Float64(float64(i)),
+			OutboundBandwidth: Float64(float64(i * 2)),
+		})
+	}
+	//when
+	inPeak := stats.Peak(TrafficDirectionInbound)
+	outPeak := stats.Peak(TrafficDirectionOutbound)
+	inP95 := stats.P95(TrafficDirectionInbound)
+	outP50 := stats.Percentile(TrafficDirectionOutbound, 50)
+	//then
+	assert.Equal(t, 20.0, inPeak, "Inbound peak matches")
+	assert.Equal(t, 40.0, outPeak, "Outbound peak matches")
+	assert.Equal(t, 19.0, inP95, "Inbound 95th percentile matches")
+	assert.Equal(t, 20.0, outP50, "Outbound median matches")
+	assert.Equal(t, 40.0, Float64Value(stats.Utilization(40)), "Utilization matches")
+	assert.Nil(t, TrafficStatistics{}.Utilization(40), "Utilization is nil without capacity")
+	assert.Equal(t, 0.0, TrafficStatistics{}.P95(TrafficDirectionInbound), "Percentile of empty series is zero")
+}
+
+func TestMapTrafficStatisticsAPIToDomain_units(t *testing.T) {
+	//given
+	units := []*string{nil, String("Mbps"), String("Gbps"), String("Kbps"), String("bps")}
+	expected := []float64{1500, 1500, 1500000, 1.5, 0.0015}
+	unknown := api.TrafficStatisticsResponse{Unit: String("packets")}
+	//when
+	output := make([]float64, len(units))
+	for i := range units {
+		stats, err := mapTrafficStatisticsAPIToDomain(api.TrafficStatisticsResponse{
+			Unit:    units[i],
+			Metrics: []api.TrafficMetric{{InboundBandwidth: Float64(1500)}},
+		})
+		assert.Nil(t, err, "Mapping should not return an error")
+		output[i] = Float64Value(stats.Samples[0].InboundBandwidth)
+	}
+	_, err := mapTrafficStatisticsAPIToDomain(unknown)
+	//then
+	assert.InDeltaSlice(t, expected, output, 1e-9, "Bandwidth is converted to megabits per second")
+	assert.NotNil(t, err, "Unknown unit results in an error")
+}
+
+func verifyTrafficStatistics(t *testing.T, stats TrafficStatistics, apiStats api.TrafficStatisticsResponse) {
+	assert.Equal(t, len(apiStats.Metrics), len(stats.Samples), "Number of samples matches")
+	for i := range apiStats.Metrics {
+		assert.Equal(t, apiStats.Metrics[i].InboundBandwidth, stats.Samples[i].InboundBandwidth, "InboundBandwidth matches")
+		assert.Equal(t, apiStats.Metrics[i].OutboundBandwidth, stats.Samples[i].OutboundBandwidth, "OutboundBandwidth matches")
+		assert.Equal(t, apiStats.Metrics[i].InboundPacketsDropped, stats.Samples[i].InboundPacketsDropped, "InboundPacketsDropped matches")
+		assert.Equal(t, apiStats.Metrics[i].OutboundPacketsDropped, stats.Samples[i].OutboundPacketsDropped, "OutboundPacketsDropped matches")
+		assert.Equal(t, StringValue(apiStats.Metrics[i].IntervalEndDateTime), stats.Samples[i].IntervalEndDateTime.Format(time.RFC3339), "IntervalEndDateTime matches")
+	}
+}
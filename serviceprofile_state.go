@@ -0,0 +1,72 @@
+package ecx
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+//L2ServiceProfileState describes state of a layer 2 service profile (L2ServiceProfile.State).
+//Profiles are submitted for approval when created and become usable once approved
+type L2ServiceProfileState string
+
+const (
+	//L2ServiceProfileStateDraft indicates that profile was saved but not submitted for approval
+	L2ServiceProfileStateDraft L2ServiceProfileState = "DRAFT"
+	//L2ServiceProfileStatePendingApproval indicates that profile awaits Equinix approval
+	L2ServiceProfileStatePendingApproval L2ServiceProfileState = "PENDING_APPROVAL"
+	//L2ServiceProfileStateApproved indicates that profile is approved and can be used
+	L2ServiceProfileStateApproved L2ServiceProfileState = "APPROVED"
+	//L2ServiceProfileStateRejected indicates that profile was rejected during approval
+	L2ServiceProfileStateRejected L2ServiceProfileState = "REJECTED"
+	//L2ServiceProfileStateDeleted indicates that profile was deleted
+	L2ServiceProfileStateDeleted L2ServiceProfileState = "DELETED"
+)
+
+const defaultServiceProfileWaitInterval = 30 * time.Second
+
+//IsTerminal checks if profile in a given state will not change its state anymore
+func (s L2ServiceProfileState) IsTerminal() bool {
+	return s == L2ServiceProfileStateRejected || s == L2ServiceProfileStateDeleted
+}
+
+//IsUsable checks if profile in a given state can be used to create connections
+func (s L2ServiceProfileState) IsUsable() bool {
+	return s == L2ServiceProfileStateApproved
+}
+
+//WaitForL2ServiceProfileState polls service profile with a given UUID, using given client
+//and interval, until profile reaches one of given states. Error is returned when profile reaches
+//terminal state that is not one of given states, when profile cannot be fetched or when
+//given context is done. Zero interval uses default polling interval
+func WaitForL2ServiceProfileState(ctx context.Context, client Client, uuid string, interval time.Duration,
+	states ...L2ServiceProfileState) (*L2ServiceProfile, error) {
+	if len(states) == 0 {
+		return nil, fmt.Errorf("at least one target service profile state needs to be given")
+	}
+	if interval <= 0 {
+		interval = defaultServiceProfileWaitInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		profile, err := client.GetL2ServiceProfile(uuid)
+		if err != nil {
+			return nil, err
+		}
+		state := L2ServiceProfileState(StringValue(profile.State))
+		for _, target := range states {
+			if state == target {
+				return profile, nil
+			}
+		}
+		if state.IsTerminal() {
+			return profile, fmt.Errorf("service profile %q reached terminal state %q", uuid, state)
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return profile, fmt.Errorf("waiting for service profile %q state: %s", uuid, ctx.Err())
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package ecx
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/equinix/ecx-go/v2/internal/api"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func registerProfileStateResponder(testHc *http.Client, profile api.L2ServiceProfile, states []string) *int {
+	gets := 0
+	httpmock.ActivateNonDefault(testHc)
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ecx/v3/l2/serviceprofiles/%s", baseURL, StringValue(profile.UUID)),
+		func(r *http.Request) (*http.Response, error) {
+			state := states[len(states)-1]
+			if gets < len(states) {
+				state = states[gets]
+			}
+			gets++
+			profile.State = String(state)
+			resp, _ := httpmock.NewJsonResponse(200, profile)
+			return resp, nil
+		},
+	)
+	return &gets
+}
+
+func TestWaitForL2ServiceProfileState(t *testing.T) {
+	//Given
+	profile := readProfileUpdateTestData(t)
+	testHc := &http.Client{}
+	gets := registerProfileStateResponder(testHc, profile, []string{"PENDING_APPROVAL", "PENDING_APPROVAL", "APPROVED"})
+	defer httpmock.DeactivateAndReset()
+	ecxClient := NewClient(context.Background(), baseURL, testHc)
+
+	//When
+	result, err := WaitForL2ServiceProfileState(context.Background(), ecxClient, StringValue(profile.UUID),
+		time.Millisecond, L2ServiceProfileStateApproved)
+
+	//Then
+	assert.Nil(t, err, "Wait should not return an error")
+	assert.Equal(t, 3, *gets, "Profile was polled until approved")
+	assert.Equal(t, string(L2ServiceProfileStateApproved), StringValue(result.State), "State matches")
+}
+
+func TestWaitForL2ServiceProfileState_terminal(t *testing.T) {
+	//Given
+	profile := readProfileUpdateTestData(t)
+	testHc := &http.Client{}
+	registerProfileStateResponder(testHc, profile, []string{"PENDING_APPROVAL", "REJECTED"})
+	defer httpmock.DeactivateAndReset()
+	ecxClient := NewClient(context.Background(), baseURL, testHc)
+
+	//When
+	result, err := WaitForL2ServiceProfileState(context.Background(), ecxClient, StringValue(profile.UUID),
+		time.Millisecond, L2ServiceProfileStateApproved)
+
+	//Then
+	assert.NotNil(t, err, "Wait should return an error")
+	assert.Equal(t, string(L2ServiceProfileStateRejected), StringValue(result.State), "State matches")
+}
+
+func TestWaitForL2ServiceProfileState_cancelled(t *testing.T) {
+	//Given
+	profile := readProfileUpdateTestData(t)
+	testHc := &http.Client{}
+	registerProfileStateResponder(testHc, profile, []string{"PENDING_APPROVAL"})
+	defer httpmock.DeactivateAndReset()
+	ecxClient := NewClient(context.Background(), baseURL, testHc)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	//When
+	_, err := WaitForL2ServiceProfileState(ctx, ecxClient, StringValue(profile.UUID),
+		time.Millisecond, L2ServiceProfileStateApproved)
+
+	//Then
+	assert.NotNil(t, err, "Wait should return an error")
+}
+
+func TestL2ServiceProfileState(t *testing.T) {
+	assert.True(t, L2ServiceProfileStateApproved.IsUsable(), "Approved profile is usable")
+	assert.False(t, L2ServiceProfileStatePendingApproval.IsUsable(), "Pending profile is not usable")
+	assert.True(t, L2ServiceProfileStateRejected.IsTerminal(), "Rejected state is terminal")
+	assert.True(t, L2ServiceProfileStateDeleted.IsTerminal(), "Deleted state is terminal")
+	assert.False(t, L2ServiceProfileStateDraft.IsTerminal(), "Draft state is not terminal")
+}
@@ -0,0 +1,59 @@
+package ecx
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"text/template"
+)
+
+//L2ServiceProfileTemplate renders layer 2 service profiles from a profile spec.
+//Spec is a Go text/template that produces JSON document with L2ServiceProfile fields,
+//i.e. {"Name": "{{ .Name }}", "SpeedBands": [{"Speed": 50, "SpeedUnit": "MB"}]}.
+//Field names are matched case insensitive and unknown fields are rejected.
+//Besides standard template functions, "json" function encodes given value as JSON
+type L2ServiceProfileTemplate struct {
+	tmpl *template.Template
+}
+
+//ParseL2ServiceProfileTemplate parses service profile spec template with a given name and text
+func ParseL2ServiceProfileTemplate(name string, text string) (*L2ServiceProfileTemplate, error) {
+	tmpl, err := template.New(name).Funcs(serviceProfileTemplateFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	return &L2ServiceProfileTemplate{tmpl: tmpl}, nil
+}
+
+//ParseL2ServiceProfileTemplateFile parses service profile spec template from a given file
+func ParseL2ServiceProfileTemplateFile(path string) (*L2ServiceProfileTemplate, error) {
+	text, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseL2ServiceProfileTemplate(filepath.Base(path), string(text))
+}
+
+//Render executes template with given variables and decodes result to service profile structure
+func (t *L2ServiceProfileTemplate) Render(vars interface{}) (*L2ServiceProfile, error) {
+	var buf bytes.Buffer
+	if err := t.tmpl.Execute(&buf, vars); err != nil {
+		return nil, err
+	}
+	profile := L2ServiceProfile{}
+	decoder := json.NewDecoder(&buf)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&profile); err != nil {
+		return nil, fmt.Errorf("cannot decode service profile rendered from template %q: %s", t.tmpl.Name(), err)
+	}
+	return &profile, nil
+}
+
+var serviceProfileTemplateFuncs = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+}
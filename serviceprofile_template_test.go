@@ -0,0 +1,71 @@
+package ecx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestL2ServiceProfileTemplate_Render(t *testing.T) {
+	//Given
+	tmpl, err := ParseL2ServiceProfileTemplateFile("./test-fixtures/ecx_l2serviceprofile_template.json.tmpl")
+	if err != nil {
+		assert.Failf(t, "Cannot parse test template due to %s", err.Error())
+	}
+	vars := map[string]interface{}{
+		"Name":    `AM "private" profile`,
+		"Metro":   "AM",
+		"Private": true,
+		"Emails":  []string{"john@example.com"},
+		"Speeds":  []int{50, 500},
+		"Ports":   []string{"port-1", "port-2"},
+	}
+
+	//When
+	profile, err := tmpl.Render(vars)
+
+	//Then
+	assert.Nil(t, err, "Render should not return an error")
+	assert.Equal(t, `AM "private" profile`, StringValue(profile.Name), "Name matches")
+	assert.Equal(t, "Profile for AM metro", StringValue(profile.Description), "Description matches")
+	assert.True(t, BoolValue(profile.Private), "Private matches")
+	assert.Equal(t, []string{"john@example.com"}, profile.PrivateUserEmails, "PrivateUserEmails match")
+	assert.Equal(t, []L2ServiceProfileSpeedBand{
+		{Speed: Int(50), SpeedUnit: String("MB")},
+		{Speed: Int(500), SpeedUnit: String("MB")},
+	}, profile.SpeedBands, "SpeedBands match")
+	assert.Equal(t, []L2ServiceProfilePort{
+		{ID: String("port-1"), MetroCode: String("AM")},
+		{ID: String("port-2"), MetroCode: String("AM")},
+	}, profile.Ports, "Ports match")
+	assert.True(t, BoolValue(profile.Features.CloudReach), "CloudReach matches")
+	assert.Nil(t, profile.UUID, "UUID is not set")
+}
+
+func TestL2ServiceProfileTemplate_Render_invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		vars interface{}
+	}{
+		{"missing variable", `{"Name": {{ json .Name }}}`, map[string]interface{}{}},
+		{"unknown field", `{"Nmae": "name"}`, nil},
+		{"malformed document", `{"Name": {{ .Name }}}`, map[string]interface{}{"Name": "name"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Given
+			tmpl, err := ParseL2ServiceProfileTemplate(tc.name, tc.text)
+			if err != nil {
+				assert.Failf(t, "Cannot parse test template due to %s", err.Error())
+			}
+
+			//When
+			profile, err := tmpl.Render(tc.vars)
+
+			//Then
+			assert.NotNil(t, err, "Render should return an error")
+			assert.Nil(t, profile, "Render should not return a profile")
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package ecx
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/equinix/rest-go"
+	"github.com/go-resty/resty/v2"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	//TracerName is a name of OpenTelemetry tracer that creates client spans
+	TracerName = "github.com/equinix/ecx-go/v2"
+
+	//TraceAttributeConnectionUUID is a span attribute with layer 2 connection UUID
+	TraceAttributeConnectionUUID = attribute.Key("ecx.connection.uuid")
+	//TraceAttributeServiceProfileUUID is a span attribute with layer 2 service profile UUID
+	TraceAttributeServiceProfileUUID = attribute.Key("ecx.service_profile.uuid")
+	//TraceAttributePortUUID is a span attribute with user port UUID
+	TraceAttributePortUUID = attribute.Key("ecx.port.uuid")
+	//TraceAttributeDeviceUUID is a span attribute with Network Edge device UUID
+	TraceAttributeDeviceUUID = attribute.Key("ecx.device.uuid")
+	//TraceAttributeEventSubscriptionUUID is a span attribute with event subscription UUID
+	TraceAttributeEventSubscriptionUUID = attribute.Key("ecx.event_subscription.uuid")
+	//TraceAttributeErrorCode is a span attribute with comma separated Equinix Fabric
+	//application error codes, i.e. IC-LAYER2-4021
+	TraceAttributeErrorCode = attribute.Key("ecx.error.code")
+	//TraceAttributePageNumber is a span attribute with page number of paginated collection.
+	//First page is requested without number and has no page number attribute
+	TraceAttributePageNumber = attribute.Key("ecx.page.number")
+	//TraceAttributePageCount is a span attribute with number of fetched pages of paginated collection
+	TraceAttributePageCount = attribute.Key("ecx.page.count")
+	//TraceAttributeTotalCount is a span attribute with number of elements in paginated collection
+	TraceAttributeTotalCount = attribute.Key("ecx.total.count")
+)
+
+//pathParamAttributes maps API path template parameters to span attributes
+var pathParamAttributes = map[string]attribute.Key{
+	"connectionUUID":   TraceAttributeConnectionUUID,
+	"profileUUID":      TraceAttributeServiceProfileUUID,
+	"portUUID":         TraceAttributePortUUID,
+	"deviceUUID":       TraceAttributeDeviceUUID,
+	"subscriptionUUID": TraceAttributeEventSubscriptionUUID,
+}
+
+//SetTracerProvider sets OpenTelemetry tracer provider used to trace client operations,
+//i.e. otel.GetTracerProvider(). Each operation creates a span named after the operation,
+//paginated operations create child span for each page. Trace context is propagated on
+//API requests with W3C traceparent header. Client without tracer provider, which is the
+//default, does not create spans
+func (c *RestClient) SetTracerProvider(provider trace.TracerProvider) *RestClient {
+	c.tracer = nil
+	if provider != nil {
+		c.tracer = provider.Tracer(TracerName)
+	}
+	return c
+}
+
+//startSpan starts span with a given name as a child of client's context and returns
+//client copy that carries the span
+func (c RestClient) startSpan(name string, kind trace.SpanKind, attributes ...attribute.KeyValue) (RestClient, trace.Span) {
+	if c.tracer == nil {
+		return c, trace.SpanFromContext(context.Background())
+	}
+	ctx := c.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, span := c.tracer.Start(ctx, name, trace.WithSpanKind(kind), trace.WithAttributes(attributes...))
+	c.ctx = ctx
+	return c, span
+}
+
+//injectTraceContext adds W3C trace context of a span carried by a given context
+//to request headers
+func (c RestClient) injectTraceContext(ctx context.Context, req *resty.Request) {
+	if c.tracer == nil || !trace.SpanContextFromContext(ctx).IsValid() {
+		return
+	}
+	propagation.TraceContext{}.Inject(ctx, propagation.HeaderCarrier(req.Header))
+}
+
+//requestSpanAttributes returns attributes describing request with a given method and path
+//or nil when client does not trace operations
+func (c RestClient) requestSpanAttributes(method string, path apiPath) []attribute.KeyValue {
+	if c.tracer == nil {
+		return nil
+	}
+	attributes := []attribute.KeyValue{
+		semconv.HTTPMethodKey.String(method),
+		semconv.HTTPRouteKey.String(path.template),
+	}
+	path.params(func(name string, value string) {
+		if key, ok := pathParamAttributes[name]; ok {
+			attributes = append(attributes, key.String(value))
+		}
+	})
+	return attributes
+}
+
+func endRequestSpan(span trace.Span, statusCode int, err error) {
+	if statusCode > 0 {
+		span.SetAttributes(semconv.HTTPStatusCodeKey.Int(statusCode))
+	}
+	endSpan(span, err)
+}
+
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		restErr := rest.Error{}
+		if errors.As(err, &restErr) && len(restErr.ApplicationErrors) > 0 {
+			errCodes := make([]string, len(restErr.ApplicationErrors))
+			for i := range restErr.ApplicationErrors {
+				errCodes[i] = restErr.ApplicationErrors[i].Code
+			}
+			span.SetAttributes(TraceAttributeErrorCode.String(strings.Join(errCodes, ",")))
+		}
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
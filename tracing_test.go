@@ -0,0 +1,188 @@
+package ecx
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func newTestTracerProvider() (*sdktrace.TracerProvider, *tracetest.SpanRecorder) {
+	recorder := tracetest.NewSpanRecorder()
+	return sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)), recorder
+}
+
+func spanAttributes(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	attributes := make(map[attribute.Key]attribute.Value)
+	for _, kv := range span.Attributes() {
+		attributes[kv.Key] = kv.Value
+	}
+	return attributes
+}
+
+func TestTracing_operation(t *testing.T) {
+	//Given
+	connID := "connId"
+	var propagated trace.SpanContext
+	testHc := &http.Client{}
+	httpmock.ActivateNonDefault(testHc)
+	httpmock.RegisterResponder("DELETE", fmt.Sprintf("%s/ecx/v3/l2/connections/%s", baseURL, connID),
+		func(r *http.Request) (*http.Response, error) {
+			ctx := propagation.TraceContext{}.Extract(context.Background(), propagation.HeaderCarrier(r.Header))
+			propagated = trace.SpanContextFromContext(ctx)
+			return httpmock.NewStringResponse(400, `[{"errorCode":"IC-LAYER2-4021","errorMessage":"Connection cannot be deleted"}]`), nil
+		},
+	)
+	defer httpmock.DeactivateAndReset()
+	provider, recorder := newTestTracerProvider()
+	ecxClient := NewClient(context.Background(), baseURL, testHc).SetTracerProvider(provider)
+
+	//When
+	err := ecxClient.DeleteL2Connection(connID)
+
+	//Then
+	assert.NotNil(t, err, "Client should return an error")
+	spans := recorder.Ended()
+	assert.Equal(t, 1, len(spans), "Number of spans matches")
+	span := spans[0]
+	assert.Equal(t, "DeleteL2Connection", span.Name(), "Span name matches")
+	assert.Equal(t, trace.SpanKindClient, span.SpanKind(), "Span kind matches")
+	assert.Equal(t, codes.Error, span.Status().Code, "Span status matches")
+	attributes := spanAttributes(span)
+	assert.Equal(t, "DELETE", attributes["http.method"].AsString(), "Method attribute matches")
+	assert.Equal(t, "/ecx/v3/l2/connections/{connectionUUID}", attributes["http.route"].AsString(), "Path template attribute matches")
+	assert.Equal(t, int64(400), attributes["http.status_code"].AsInt64(), "Status code attribute matches")
+	assert.Equal(t, connID, attributes[TraceAttributeConnectionUUID].AsString(), "Connection UUID attribute matches")
+	assert.Equal(t, "IC-LAYER2-4021", attributes[TraceAttributeErrorCode].AsString(), "Error code attribute matches")
+	assert.Equal(t, span.SpanContext().TraceID(), propagated.TraceID(), "Trace ID is propagated")
+	assert.Equal(t, span.SpanContext().SpanID(), propagated.SpanID(), "Span ID is propagated")
+}
+
+func TestTracing_retryingInterceptor(t *testing.T) {
+	//Given
+	var mu sync.Mutex
+	var propagated []trace.SpanContext
+	testHc := &http.Client{}
+	httpmock.ActivateNonDefault(testHc)
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ecx/v3/port/userport", baseURL),
+		func(r *http.Request) (*http.Response, error) {
+			ctx := propagation.TraceContext{}.Extract(context.Background(), propagation.HeaderCarrier(r.Header))
+			mu.Lock()
+			defer mu.Unlock()
+			propagated = append(propagated, trace.SpanContextFromContext(ctx))
+			if len(propagated) == 1 {
+				return httpmock.NewStringResponse(503, ""), nil
+			}
+			return httpmock.NewStringResponse(200, "[]"), nil
+		},
+	)
+	defer httpmock.DeactivateAndReset()
+	retry := func(next Invoker) Invoker {
+		return func(inv *Invocation) error {
+			err := next(inv)
+			if inv.StatusCode == http.StatusServiceUnavailable {
+				err = next(inv)
+			}
+			return err
+		}
+	}
+	provider, recorder := newTestTracerProvider()
+	ecxClient := NewClient(context.Background(), baseURL, testHc, WithInterceptors(retry)).SetTracerProvider(provider)
+
+	//When
+	_, err := ecxClient.GetUserPorts()
+
+	//Then
+	assert.Nil(t, err, "Client should not return an error")
+	spans := recorder.Ended()
+	assert.Equal(t, 1, len(spans), "Number of spans matches")
+	assert.Equal(t, "GetUserPorts", spans[0].Name(), "Span name matches")
+	assert.Equal(t, int64(200), spanAttributes(spans[0])["http.status_code"].AsInt64(), "Status code attribute matches")
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, 2, len(propagated), "Request is retried")
+	for _, spanCtx := range propagated {
+		assert.Equal(t, spans[0].SpanContext().SpanID(), spanCtx.SpanID(), "Retried request is sent within operation span")
+	}
+}
+
+func TestTracing_pages(t *testing.T) {
+	//Given
+	pages := &connectionPages{total: 7, page: pagedTestConnections(7, 3)}
+	testHc := &http.Client{}
+	registerConnectionPages(testHc, pages)
+	defer httpmock.DeactivateAndReset()
+	provider, recorder := newTestTracerProvider()
+	ecxClient := NewClient(context.Background(), baseURL, testHc).SetTracerProvider(provider)
+	ecxClient.SetPageSize(3)
+
+	//When
+	_, err := ecxClient.GetL2OutgoingConnections(nil)
+
+	//Then
+	assert.Nil(t, err, "Client should not return an error")
+	spans := recorder.Ended()
+	assert.Equal(t, 4, len(spans), "Number of spans matches")
+	operation := spans[len(spans)-1]
+	assert.Equal(t, "GetL2OutgoingConnections", operation.Name(), "Operation span name matches")
+	assert.Equal(t, int64(3), spanAttributes(operation)[TraceAttributePageCount].AsInt64(), "Page count attribute matches")
+	assert.Equal(t, int64(7), spanAttributes(operation)[TraceAttributeTotalCount].AsInt64(), "Total count attribute matches")
+	pageNumbers := make(map[int64]bool)
+	for _, span := range spans[:len(spans)-1] {
+		assert.Equal(t, "GetL2OutgoingConnections page", span.Name(), "Page span name matches")
+		assert.Equal(t, operation.SpanContext().SpanID(), span.Parent().SpanID(), "Page span is a child of operation span")
+		attributes := spanAttributes(span)
+		assert.Equal(t, "/ecx/v3/l2/buyer/connections", attributes["http.route"].AsString(), "Path template attribute matches")
+		if number, ok := attributes[TraceAttributePageNumber]; ok {
+			pageNumbers[number.AsInt64()] = true
+		}
+	}
+	assert.Equal(t, map[int64]bool{1: true, 2: true}, pageNumbers, "Page number attributes match")
+}
+
+func TestTracing_disabled(t *testing.T) {
+	//Given
+	traceparent := "unset"
+	testHc := &http.Client{}
+	httpmock.ActivateNonDefault(testHc)
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ecx/v3/port/userport", baseURL),
+		func(r *http.Request) (*http.Response, error) {
+			traceparent = r.Header.Get("traceparent")
+			return httpmock.NewStringResponse(200, "[]"), nil
+		},
+	)
+	defer httpmock.DeactivateAndReset()
+	ecxClient := NewClient(context.Background(), baseURL, testHc)
+
+	//When
+	_, err := ecxClient.GetUserPorts()
+
+	//Then
+	assert.Nil(t, err, "Client should not return an error")
+	assert.Empty(t, traceparent, "Trace context is not propagated")
+}
+
+func TestAPIPath(t *testing.T) {
+	//Given
+	path := newAPIPath("/ecx/v3/l2/connections/{connectionUUID}/stats", "a/b c")
+
+	//When
+	params := make(map[string]string)
+	path.params(func(name string, value string) {
+		params[name] = value
+	})
+
+	//Then
+	assert.Equal(t, "/ecx/v3/l2/connections/a%2Fb%20c/stats", path.String(), "Path matches")
+	assert.Equal(t, map[string]string{"connectionUUID": "a/b c"}, params, "Params match")
+}
@@ -0,0 +1,198 @@
+package ecx
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+const (
+	//PortEncapsulationDot1q indicates port that uses single 802.1Q VLAN tag
+	PortEncapsulationDot1q = "DOT1Q"
+	//PortEncapsulationQinq indicates port that uses stacked 802.1ad VLAN tags (S-Tag and C-Tag)
+	PortEncapsulationQinq = "QINQ"
+)
+
+var (
+	defaultVlanLocker      = &sync.Mutex{}
+	defaultVlanAllocations = &vlanAllocations{ports: make(map[string]map[vlanPair]struct{})}
+)
+
+//VlanRange describes inclusive range of VLAN tags
+type VlanRange struct {
+	From int
+	To   int
+}
+
+//VlanAllocation describes VLAN tags allocated on a user port.
+//CTag is set only for ports with QINQ encapsulation
+type VlanAllocation struct {
+	PortUUID      *string
+	Encapsulation *string
+	STag          *int
+	CTag          *int
+}
+
+//VlanAllocator finds VLAN tags that do not collide with tags used by existing connections
+//on a given user port. Tags handed out by any allocator in the process are remembered until
+//they are released or become used by a connection. Tags are reserved atomically, so concurrent
+//allocations in the process never return the same tag, regardless of configured locks
+type VlanAllocator struct {
+	client      Client
+	reserved    []VlanRange
+	locker      sync.Locker
+	allocations *vlanAllocations
+}
+
+type vlanPair struct {
+	sTag int
+	cTag int
+}
+
+type vlanAllocations struct {
+	mu    sync.Mutex
+	ports map[string]map[vlanPair]struct{}
+}
+
+//NewVlanAllocator creates new VLAN allocator that uses given client to fetch port details
+//and existing connections
+func NewVlanAllocator(client Client) *VlanAllocator {
+	return &VlanAllocator{
+		client:      client,
+		locker:      defaultVlanLocker,
+		allocations: defaultVlanAllocations,
+	}
+}
+
+//WithReservedRange excludes given inclusive range of S-Tags from allocation.
+//C-Tags of QINQ ports are not affected by reserved ranges
+func (a *VlanAllocator) WithReservedRange(from int, to int) *VlanAllocator {
+	a.reserved = append(a.reserved, VlanRange{From: from, To: to})
+	return a
+}
+
+//WithLocker sets lock used to serialize allocations, i.e. lock shared with other
+//processes that create connections on the same ports. By default, single lock shared
+//by all allocators in the process is used. Uniqueness of tags allocated in the process
+//does not depend on the lock
+func (a *VlanAllocator) WithLocker(locker sync.Locker) *VlanAllocator {
+	a.locker = locker
+	return a
+}
+
+//Allocate returns next free VLAN tag on a user port with a given UUID.
+//For ports with DOT1Q encapsulation, S-Tag is allocated. For ports with QINQ
+//encapsulation, S-Tag and C-Tag pair is allocated
+func (a *VlanAllocator) Allocate(portUUID string) (*VlanAllocation, error) {
+	a.locker.Lock()
+	defer a.locker.Unlock()
+	availability, err := a.client.GetPortVlanAvailability(portUUID)
+	if err != nil {
+		return nil, err
+	}
+	a.allocations.forget(portUUID, availability.UsedVlans)
+	allocation := &VlanAllocation{
+		PortUUID:      String(portUUID),
+		Encapsulation: availability.Encapsulation,
+	}
+	switch strings.ToUpper(StringValue(availability.Encapsulation)) {
+	case PortEncapsulationDot1q:
+		sTag, ok := a.reserveSTag(portUUID, *availability)
+		if !ok {
+			return nil, fmt.Errorf("no free S-Tag available on port %q", portUUID)
+		}
+		allocation.STag = Int(sTag)
+	case PortEncapsulationQinq:
+		sTag, cTag, ok := a.reservePair(portUUID, *availability)
+		if !ok {
+			return nil, fmt.Errorf("no free S-Tag and C-Tag pair available on port %q", portUUID)
+		}
+		allocation.STag = Int(sTag)
+		allocation.CTag = Int(cTag)
+	default:
+		return nil, fmt.Errorf("port %q has unsupported encapsulation %q", portUUID, StringValue(availability.Encapsulation))
+	}
+	return allocation, nil
+}
+
+//Release returns allocated VLAN tags back to the pool, i.e. when connection
+//creation has failed
+func (a *VlanAllocator) Release(allocation VlanAllocation) {
+	a.allocations.remove(StringValue(allocation.PortUUID), vlanPair{
+		sTag: IntValue(allocation.STag),
+		cTag: IntValue(allocation.CTag),
+	})
+}
+
+func (a *VlanAllocator) reserveSTag(portUUID string, availability PortVlanAvailability) (int, bool) {
+	for sTag := VlanTagMin; sTag <= VlanTagMax; sTag++ {
+		if a.isReserved(sTag) || !availability.IsSTagAvailable(sTag) {
+			continue
+		}
+		if a.allocations.addIfAbsent(portUUID, vlanPair{sTag: sTag}, true) {
+			return sTag, true
+		}
+	}
+	return 0, false
+}
+
+func (a *VlanAllocator) reservePair(portUUID string, availability PortVlanAvailability) (int, int, bool) {
+	for sTag := VlanTagMin; sTag <= VlanTagMax; sTag++ {
+		if a.isReserved(sTag) {
+			continue
+		}
+		for cTag := VlanTagMin; cTag <= VlanTagMax; cTag++ {
+			if !availability.IsAvailable(sTag, cTag) {
+				continue
+			}
+			if a.allocations.addIfAbsent(portUUID, vlanPair{sTag: sTag, cTag: cTag}, false) {
+				return sTag, cTag, true
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+func (a *VlanAllocator) isReserved(tag int) bool {
+	for _, r := range a.reserved {
+		if tag >= r.From && tag <= r.To {
+			return true
+		}
+	}
+	return false
+}
+
+//addIfAbsent adds allocation of a given pair unless it collides with existing allocation
+//on a given port. When wholeSTag is set, any allocation with pair's S-Tag collides.
+//Returns true if allocation was added
+func (v *vlanAllocations) addIfAbsent(portUUID string, pair vlanPair, wholeSTag bool) bool {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	allocated, ok := v.ports[portUUID]
+	if !ok {
+		allocated = make(map[vlanPair]struct{})
+		v.ports[portUUID] = allocated
+	}
+	for existing := range allocated {
+		if existing == pair || (wholeSTag && existing.sTag == pair.sTag) {
+			return false
+		}
+	}
+	allocated[pair] = struct{}{}
+	return true
+}
+
+func (v *vlanAllocations) remove(portUUID string, pair vlanPair) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	delete(v.ports[portUUID], pair)
+}
+
+//forget removes allocations that are already used by connections
+func (v *vlanAllocations) forget(portUUID string, used []PortVlan) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	for _, vlan := range used {
+		delete(v.ports[portUUID], vlanPair{sTag: IntValue(vlan.STag), cTag: IntValue(vlan.CTag)})
+	}
+}
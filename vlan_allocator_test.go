@@ -0,0 +1,146 @@
+package ecx
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVlanAllocator_AllocateDot1q(t *testing.T) {
+	//Given
+	portRespBody, connsRespBody := readPortConnectionsTestData(t)
+	portID := StringValue(portRespBody.UUID)
+	testHc := &http.Client{}
+	registerPortConnectionsResponders(testHc, portRespBody, connsRespBody)
+	defer httpmock.DeactivateAndReset()
+	ecxClient := NewClient(context.Background(), baseURL, testHc)
+	ecxClient.SetPageSize(IntValue(connsRespBody.PageSize))
+	allocator := newTestVlanAllocator(ecxClient).WithReservedRange(2, 129)
+
+	//When
+	first, err := allocator.Allocate(portID)
+	assert.Nil(t, err, "Allocator should not return an error")
+	second, err := allocator.Allocate(portID)
+	assert.Nil(t, err, "Allocator should not return an error")
+	allocator.Release(*first)
+	third, err := allocator.Allocate(portID)
+	assert.Nil(t, err, "Allocator should not return an error")
+
+	//Then
+	assert.Equal(t, 131, IntValue(first.STag), "First S-Tag skips reserved range and used tags")
+	assert.Nil(t, first.CTag, "C-Tag is not allocated for DOT1Q port")
+	assert.Equal(t, 132, IntValue(second.STag), "Second S-Tag skips already allocated tag")
+	assert.Equal(t, 131, IntValue(third.STag), "Released S-Tag is allocated again")
+}
+
+func TestVlanAllocator_AllocateQinq(t *testing.T) {
+	//Given
+	portRespBody, connsRespBody := readPortConnectionsTestData(t)
+	portRespBody.Encapsulation = String("Qinq")
+	portID := StringValue(portRespBody.UUID)
+	testHc := &http.Client{}
+	registerPortConnectionsResponders(testHc, portRespBody, connsRespBody)
+	defer httpmock.DeactivateAndReset()
+	ecxClient := NewClient(context.Background(), baseURL, testHc)
+	ecxClient.SetPageSize(IntValue(connsRespBody.PageSize))
+	allocator := newTestVlanAllocator(ecxClient).WithReservedRange(2, 129)
+
+	//When
+	first, err := allocator.Allocate(portID)
+	assert.Nil(t, err, "Allocator should not return an error")
+	second, err := allocator.Allocate(portID)
+	assert.Nil(t, err, "Allocator should not return an error")
+
+	//Then
+	assert.Equal(t, 131, IntValue(first.STag), "S-Tag skips reserved range and tags used without C-Tag")
+	assert.Equal(t, 131, IntValue(second.STag), "S-Tag with free C-Tags is reused")
+	assert.Equal(t, 2, IntValue(first.CTag), "C-Tag is not affected by reserved range")
+	assert.Equal(t, 3, IntValue(second.CTag), "C-Tag skips already allocated tag")
+}
+
+func TestVlanAllocator_ConcurrentAllocations(t *testing.T) {
+	//Given
+	portRespBody, connsRespBody := readPortConnectionsTestData(t)
+	portID := StringValue(portRespBody.UUID)
+	testHc := &http.Client{}
+	registerPortConnectionsResponders(testHc, portRespBody, connsRespBody)
+	defer httpmock.DeactivateAndReset()
+	ecxClient := NewClient(context.Background(), baseURL, testHc)
+	ecxClient.SetPageSize(IntValue(connsRespBody.PageSize))
+	first := newTestVlanAllocator(ecxClient)
+	second := NewVlanAllocator(ecxClient).WithLocker(first.locker)
+	second.allocations = first.allocations
+	allocationsNumber := 20
+
+	//When
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	allocated := make(map[int]int)
+	for _, allocator := range []*VlanAllocator{first, second} {
+		for i := 0; i < allocationsNumber; i++ {
+			wg.Add(1)
+			go func(allocator *VlanAllocator) {
+				defer wg.Done()
+				allocation, err := allocator.Allocate(portID)
+				assert.Nil(t, err, "Allocator should not return an error")
+				mu.Lock()
+				allocated[IntValue(allocation.STag)]++
+				mu.Unlock()
+			}(allocator)
+		}
+	}
+	wg.Wait()
+
+	//Then
+	assert.Equal(t, 2*allocationsNumber, len(allocated), "Each allocation got distinct S-Tag")
+	assert.NotContains(t, allocated, 130, "S-Tag used by connection was not allocated")
+	assert.NotContains(t, allocated, 140, "S-Tag used by connection was not allocated")
+}
+
+func TestVlanAllocator_ConcurrentAllocationsDistinctLockers(t *testing.T) {
+	//Given
+	portRespBody, connsRespBody := readPortConnectionsTestData(t)
+	portID := StringValue(portRespBody.UUID)
+	testHc := &http.Client{}
+	registerPortConnectionsResponders(testHc, portRespBody, connsRespBody)
+	defer httpmock.DeactivateAndReset()
+	ecxClient := NewClient(context.Background(), baseURL, testHc)
+	ecxClient.SetPageSize(IntValue(connsRespBody.PageSize))
+	allocations := &vlanAllocations{ports: make(map[string]map[vlanPair]struct{})}
+	allocationsNumber := 20
+	allocators := make([]*VlanAllocator, allocationsNumber)
+	for i := range allocators {
+		allocators[i] = NewVlanAllocator(ecxClient).WithLocker(&sync.Mutex{})
+		allocators[i].allocations = allocations
+	}
+
+	//When
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	allocated := make(map[int]int)
+	for _, allocator := range allocators {
+		wg.Add(1)
+		go func(allocator *VlanAllocator) {
+			defer wg.Done()
+			allocation, err := allocator.Allocate(portID)
+			assert.Nil(t, err, "Allocator should not return an error")
+			mu.Lock()
+			allocated[IntValue(allocation.STag)]++
+			mu.Unlock()
+		}(allocator)
+	}
+	wg.Wait()
+
+	//Then
+	assert.Equal(t, allocationsNumber, len(allocated), "Each allocation got distinct S-Tag")
+}
+
+func newTestVlanAllocator(client Client) *VlanAllocator {
+	allocator := NewVlanAllocator(client).WithLocker(&sync.Mutex{})
+	allocator.allocations = &vlanAllocations{ports: make(map[string]map[vlanPair]struct{})}
+	return allocator
+}
@@ -0,0 +1,417 @@
+package ecx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/equinix/rest-go"
+)
+
+const (
+	defaultWatchInterval   = time.Minute
+	defaultWatchBufferSize = 100
+)
+
+//WatchEvent describes change of a layer 2 connection observed by a Watcher.
+//Use type switch to distinguish between event types
+type WatchEvent interface {
+	//ConnectionUUID returns UUID of a connection that has changed
+	ConnectionUUID() string
+}
+
+//ConnectionCreatedEvent is emitted when new connection appears
+type ConnectionCreatedEvent struct {
+	Connection L2Connection
+}
+
+//ConnectionDeletedEvent is emitted when connection disappears.
+//Connection holds last observed state
+type ConnectionDeletedEvent struct {
+	Connection L2Connection
+}
+
+//StatusChangedEvent is emitted when connection status changes
+type StatusChangedEvent struct {
+	Connection L2Connection
+	From       string
+	To         string
+}
+
+//ProviderStatusChangedEvent is emitted when connection provider status changes
+type ProviderStatusChangedEvent struct {
+	Connection L2Connection
+	From       string
+	To         string
+}
+
+//NameChangedEvent is emitted when connection is renamed
+type NameChangedEvent struct {
+	Connection L2Connection
+	From       string
+	To         string
+}
+
+//SpeedChangedEvent is emitted when connection speed or speed unit changes
+type SpeedChangedEvent struct {
+	Connection L2Connection
+	From       Bandwidth
+	To         Bandwidth
+}
+
+//ActionRequiredEvent is emitted when connection gains new pending action
+type ActionRequiredEvent struct {
+	Connection L2Connection
+	Action     L2ConnectionAction
+}
+
+//ConnectionUUID returns UUID of created connection
+func (e ConnectionCreatedEvent) ConnectionUUID() string { return StringValue(e.Connection.UUID) }
+
+//ConnectionUUID returns UUID of deleted connection
+func (e ConnectionDeletedEvent) ConnectionUUID() string { return StringValue(e.Connection.UUID) }
+
+//ConnectionUUID returns UUID of connection which status has changed
+func (e StatusChangedEvent) ConnectionUUID() string { return StringValue(e.Connection.UUID) }
+
+//ConnectionUUID returns UUID of connection which provider status has changed
+func (e ProviderStatusChangedEvent) ConnectionUUID() string { return StringValue(e.Connection.UUID) }
+
+//ConnectionUUID returns UUID of renamed connection
+func (e NameChangedEvent) ConnectionUUID() string { return StringValue(e.Connection.UUID) }
+
+//ConnectionUUID returns UUID of connection which speed has changed
+func (e SpeedChangedEvent) ConnectionUUID() string { return StringValue(e.Connection.UUID) }
+
+//ConnectionUUID returns UUID of connection that requires an action
+func (e ActionRequiredEvent) ConnectionUUID() string { return StringValue(e.Connection.UUID) }
+
+//Watcher periodically takes snapshots of layer 2 connections, compares them with
+//previous snapshot and emits events describing the changes.
+//First snapshot, unless restored from a state file, only establishes a baseline.
+//Watcher can be run only once
+type Watcher struct {
+	client       Client
+	interval     time.Duration
+	statuses     []string
+	uuids        []string
+	statePath    string
+	errorHandler func(error)
+	events       chan WatchEvent
+	mu           sync.Mutex
+	started      bool
+	snapshot     map[string]L2Connection
+}
+
+//NewWatcher creates new connection watcher that uses given client
+func NewWatcher(client Client) *Watcher {
+	return &Watcher{
+		client:   client,
+		interval: defaultWatchInterval,
+		events:   make(chan WatchEvent, defaultWatchBufferSize),
+	}
+}
+
+//WithInterval sets time between consecutive snapshots.
+//Default interval is used when given interval is not positive
+func (w *Watcher) WithInterval(interval time.Duration) *Watcher {
+	w.interval = interval
+	if interval <= 0 {
+		w.interval = defaultWatchInterval
+	}
+	return w
+}
+
+//WithStatuses limits outgoing connections in a snapshot to ones with given statuses.
+//Connection that changes its status to one outside of given statuses is reported
+//with StatusChangedEvent and is not watched anymore
+func (w *Watcher) WithStatuses(statuses []string) *Watcher {
+	w.statuses = statuses
+	return w
+}
+
+//WithConnections adds connections with given UUIDs to each snapshot, in addition
+//to outgoing connections. This allows to watch i.e. incoming connections
+func (w *Watcher) WithConnections(uuids ...string) *Watcher {
+	w.uuids = append(w.uuids, uuids...)
+	return w
+}
+
+//WithStateFile sets path of a file where snapshots are persisted. If file exists
+//when watcher starts, its snapshot is used as a baseline so changes that happened
+//while watcher was not running are reported. Run persists snapshot only once its
+//events were emitted, so events not emitted before Run returned are reported again
+func (w *Watcher) WithStateFile(path string) *Watcher {
+	w.statePath = path
+	return w
+}
+
+//WithErrorHandler sets function that is called when snapshot cannot be taken.
+//Watcher continues with next snapshot after an error
+func (w *Watcher) WithErrorHandler(handler func(error)) *Watcher {
+	w.errorHandler = handler
+	return w
+}
+
+//Events returns channel on which watch events are emitted.
+//Channel is closed when Run returns
+func (w *Watcher) Events() <-chan WatchEvent {
+	return w.events
+}
+
+//Run takes snapshots with configured interval and emits events until given context
+//is cancelled. Error is returned when state file cannot be read or when watcher
+//was already run
+func (w *Watcher) Run(ctx context.Context) error {
+	w.mu.Lock()
+	if w.started {
+		w.mu.Unlock()
+		return errors.New("watcher was already run")
+	}
+	w.started = true
+	w.mu.Unlock()
+	defer close(w.events)
+	if err := w.loadState(); err != nil {
+		return err
+	}
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		events, err := w.poll()
+		for _, event := range events {
+			select {
+			case w.events <- event:
+			case <-ctx.Done():
+				return nil
+			}
+		}
+		if err == nil {
+			err = w.persist()
+		}
+		if err != nil && w.errorHandler != nil {
+			w.errorHandler(err)
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+//Poll takes single snapshot, compares it with the previous one and returns
+//events describing the changes. Snapshot is persisted if state file is configured.
+//Poll can be called concurrently with Run, snapshots are taken one at a time
+func (w *Watcher) Poll() ([]WatchEvent, error) {
+	events, err := w.poll()
+	if err != nil {
+		return nil, err
+	}
+	if err := w.persist(); err != nil {
+		return events, err
+	}
+	return events, nil
+}
+
+//poll takes single snapshot, compares it with the previous one and returns
+//events describing the changes. Snapshot replaces the previous one but is not persisted
+func (w *Watcher) poll() ([]WatchEvent, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	current, err := w.takeSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	var events []WatchEvent
+	if w.snapshot != nil {
+		filteredOut, err := w.findFilteredOut(w.snapshot, current)
+		if err != nil {
+			return nil, err
+		}
+		events = diffSnapshots(w.snapshot, current, filteredOut)
+	}
+	w.snapshot = current
+	return events, nil
+}
+
+//persist saves current snapshot if state file is configured
+func (w *Watcher) persist() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.saveState()
+}
+
+func (w *Watcher) takeSnapshot() (map[string]L2Connection, error) {
+	conns, err := w.client.GetL2OutgoingConnections(w.statuses)
+	if err != nil {
+		return nil, err
+	}
+	snapshot := make(map[string]L2Connection, len(conns)+len(w.uuids))
+	for _, conn := range conns {
+		snapshot[StringValue(conn.UUID)] = conn
+	}
+	for _, uuid := range w.uuids {
+		if _, ok := snapshot[uuid]; ok {
+			continue
+		}
+		conn, err := w.getConnection(uuid)
+		if err != nil {
+			return nil, err
+		}
+		if conn != nil {
+			snapshot[uuid] = *conn
+		}
+	}
+	return snapshot, nil
+}
+
+//findFilteredOut fetches connections that are missing in current snapshot because
+//their status does not match watched statuses anymore
+func (w *Watcher) findFilteredOut(previous map[string]L2Connection, current map[string]L2Connection) (map[string]L2Connection, error) {
+	filteredOut := make(map[string]L2Connection)
+	if len(w.statuses) == 0 {
+		return filteredOut, nil
+	}
+	for _, uuid := range sortedSnapshotKeys(previous) {
+		if _, ok := current[uuid]; ok {
+			continue
+		}
+		conn, err := w.getConnection(uuid)
+		if err != nil {
+			return nil, err
+		}
+		if conn != nil && StringValue(conn.Status) != ConnectionStatusDeleted {
+			filteredOut[uuid] = *conn
+		}
+	}
+	return filteredOut, nil
+}
+
+//getConnection fetches connection with a given UUID, nil is returned when
+//connection does not exist
+func (w *Watcher) getConnection(uuid string) (*L2Connection, error) {
+	conn, err := w.client.GetL2Connection(uuid)
+	if err != nil {
+		if restErr, ok := err.(rest.Error); ok && restErr.HTTPCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return conn, nil
+}
+
+func (w *Watcher) loadState() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.statePath == "" {
+		return nil
+	}
+	data, err := ioutil.ReadFile(w.statePath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	snapshot := make(map[string]L2Connection)
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return err
+	}
+	w.snapshot = snapshot
+	return nil
+}
+
+func (w *Watcher) saveState() error {
+	if w.statePath == "" {
+		return nil
+	}
+	data, err := json.Marshal(w.snapshot)
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(w.statePath), filepath.Base(w.statePath)+".tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), w.statePath)
+}
+
+func diffSnapshots(previous map[string]L2Connection, current map[string]L2Connection, filteredOut map[string]L2Connection) []WatchEvent {
+	var events []WatchEvent
+	for _, uuid := range sortedSnapshotKeys(current) {
+		conn := current[uuid]
+		prev, ok := previous[uuid]
+		if !ok {
+			events = append(events, ConnectionCreatedEvent{Connection: conn})
+			events = append(events, diffActions(nil, conn)...)
+			continue
+		}
+		if StringValue(prev.Status) != StringValue(conn.Status) {
+			events = append(events, StatusChangedEvent{Connection: conn, From: StringValue(prev.Status), To: StringValue(conn.Status)})
+		}
+		if StringValue(prev.ProviderStatus) != StringValue(conn.ProviderStatus) {
+			events = append(events, ProviderStatusChangedEvent{Connection: conn, From: StringValue(prev.ProviderStatus), To: StringValue(conn.ProviderStatus)})
+		}
+		if StringValue(prev.Name) != StringValue(conn.Name) {
+			events = append(events, NameChangedEvent{Connection: conn, From: StringValue(prev.Name), To: StringValue(conn.Name)})
+		}
+		prevSpeed, _ := speedBandwidth(prev.Speed, prev.SpeedUnit)
+		speed, _ := speedBandwidth(conn.Speed, conn.SpeedUnit)
+		if prevSpeed != speed {
+			events = append(events, SpeedChangedEvent{Connection: conn, From: prevSpeed, To: speed})
+		}
+		events = append(events, diffActions(prev.Actions, conn)...)
+	}
+	for _, uuid := range sortedSnapshotKeys(previous) {
+		if _, ok := current[uuid]; ok {
+			continue
+		}
+		if conn, ok := filteredOut[uuid]; ok {
+			if prev := previous[uuid]; StringValue(prev.Status) != StringValue(conn.Status) {
+				events = append(events, StatusChangedEvent{Connection: conn, From: StringValue(prev.Status), To: StringValue(conn.Status)})
+			}
+			continue
+		}
+		events = append(events, ConnectionDeletedEvent{Connection: previous[uuid]})
+	}
+	return events
+}
+
+func diffActions(previous []L2ConnectionAction, conn L2Connection) []WatchEvent {
+	known := make(map[string]struct{}, len(previous))
+	for _, action := range previous {
+		known[StringValue(action.Type)+"/"+StringValue(action.OperationID)] = struct{}{}
+	}
+	var events []WatchEvent
+	for _, action := range conn.Actions {
+		if _, ok := known[StringValue(action.Type)+"/"+StringValue(action.OperationID)]; !ok {
+			events = append(events, ActionRequiredEvent{Connection: conn, Action: action})
+		}
+	}
+	return events
+}
+
+func sortedSnapshotKeys(snapshot map[string]L2Connection) []string {
+	keys := make([]string, 0, len(snapshot))
+	for key := range snapshot {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,299 @@
+package ecx
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/equinix/ecx-go/v2/internal/api"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatcher_Poll(t *testing.T) {
+	//Given
+	first, second := readWatcherTestData(t)
+	testHc := &http.Client{}
+	registerWatcherResponders(testHc, first, second)
+	defer httpmock.DeactivateAndReset()
+	ecxClient := NewClient(context.Background(), baseURL, testHc)
+	ecxClient.SetPageSize(IntValue(first.PageSize))
+	watcher := NewWatcher(ecxClient)
+
+	//When
+	baseline, err := watcher.Poll()
+	assert.Nil(t, err, "Watcher should not return an error")
+	events, err := watcher.Poll()
+	assert.Nil(t, err, "Watcher should not return an error")
+
+	//Then
+	assert.Empty(t, baseline, "First snapshot establishes a baseline")
+	verifyWatcherEvents(t, events, first)
+}
+
+func TestWatcher_RunWithStateFile(t *testing.T) {
+	//Given
+	first, second := readWatcherTestData(t)
+	testHc := &http.Client{}
+	registerWatcherResponders(testHc, first, second)
+	defer httpmock.DeactivateAndReset()
+	ecxClient := NewClient(context.Background(), baseURL, testHc)
+	ecxClient.SetPageSize(IntValue(first.PageSize))
+	dir, err := ioutil.TempDir("", "ecx-watcher")
+	if err != nil {
+		assert.Failf(t, "Cannot create temporary directory due to %s", err.Error())
+	}
+	defer os.RemoveAll(dir)
+	statePath := filepath.Join(dir, "state.json")
+	if _, err := NewWatcher(ecxClient).WithStateFile(statePath).Poll(); err != nil {
+		assert.Failf(t, "Cannot establish baseline due to %s", err.Error())
+	}
+
+	//When
+	watcher := NewWatcher(ecxClient).WithStateFile(statePath).WithInterval(time.Hour)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- watcher.Run(ctx)
+	}()
+	var events []WatchEvent
+	for len(events) < 7 {
+		select {
+		case event := <-watcher.Events():
+			events = append(events, event)
+		case <-time.After(5 * time.Second):
+			assert.Fail(t, "Watcher did not emit expected events")
+			cancel()
+			return
+		}
+	}
+	cancel()
+
+	//Then
+	assert.Nil(t, <-done, "Watcher should not return an error")
+	_, open := <-watcher.Events()
+	assert.False(t, open, "Events channel is closed")
+	verifyWatcherEvents(t, events, first)
+}
+
+func TestWatcher_RunWithStateFileCancelled(t *testing.T) {
+	//Given
+	first, second := readWatcherTestData(t)
+	testHc := &http.Client{}
+	registerWatcherResponders(testHc, first, second)
+	defer httpmock.DeactivateAndReset()
+	ecxClient := NewClient(context.Background(), baseURL, testHc)
+	ecxClient.SetPageSize(IntValue(first.PageSize))
+	dir, err := ioutil.TempDir("", "ecx-watcher")
+	if err != nil {
+		assert.Failf(t, "Cannot create temporary directory due to %s", err.Error())
+	}
+	defer os.RemoveAll(dir)
+	statePath := filepath.Join(dir, "state.json")
+	if _, err := NewWatcher(ecxClient).WithStateFile(statePath).Poll(); err != nil {
+		assert.Failf(t, "Cannot establish baseline due to %s", err.Error())
+	}
+	watcher := NewWatcher(ecxClient).WithStateFile(statePath).WithInterval(time.Hour)
+	watcher.events = make(chan WatchEvent)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	//When
+	runErr := watcher.Run(ctx)
+	restarted := NewWatcher(ecxClient).WithStateFile(statePath).WithInterval(time.Hour)
+	restartedCtx, restartedCancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- restarted.Run(restartedCtx)
+	}()
+	var events []WatchEvent
+	for len(events) < 7 {
+		select {
+		case event := <-restarted.Events():
+			events = append(events, event)
+		case <-time.After(5 * time.Second):
+			assert.Fail(t, "Watcher did not emit expected events")
+			restartedCancel()
+			return
+		}
+	}
+	restartedCancel()
+
+	//Then
+	assert.Nil(t, runErr, "Watcher should not return an error")
+	assert.Nil(t, <-done, "Watcher should not return an error")
+	verifyWatcherEvents(t, events, first)
+}
+
+func TestWatcher_WithInterval(t *testing.T) {
+	//When
+	zero := NewWatcher(nil).WithInterval(0)
+	negative := NewWatcher(nil).WithInterval(-time.Second)
+	positive := NewWatcher(nil).WithInterval(time.Second)
+
+	//Then
+	assert.Equal(t, defaultWatchInterval, zero.interval, "Zero interval is replaced with default")
+	assert.Equal(t, defaultWatchInterval, negative.interval, "Negative interval is replaced with default")
+	assert.Equal(t, time.Second, positive.interval, "Positive interval is kept")
+}
+
+func TestWatcher_RunTwice(t *testing.T) {
+	//Given
+	first, second := readWatcherTestData(t)
+	testHc := &http.Client{}
+	registerWatcherResponders(testHc, first, second)
+	defer httpmock.DeactivateAndReset()
+	ecxClient := NewClient(context.Background(), baseURL, testHc)
+	ecxClient.SetPageSize(IntValue(first.PageSize))
+	watcher := NewWatcher(ecxClient).WithInterval(time.Hour)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	//When
+	firstErr := watcher.Run(ctx)
+	secondErr := watcher.Run(ctx)
+
+	//Then
+	assert.Nil(t, firstErr, "First run should not return an error")
+	assert.NotNil(t, secondErr, "Second run should return an error")
+}
+
+func TestWatcher_PollConcurrently(t *testing.T) {
+	//Given
+	first, second := readWatcherTestData(t)
+	testHc := &http.Client{}
+	registerWatcherResponders(testHc, first, second)
+	defer httpmock.DeactivateAndReset()
+	ecxClient := NewClient(context.Background(), baseURL, testHc)
+	ecxClient.SetPageSize(IntValue(first.PageSize))
+	watcher := NewWatcher(ecxClient)
+	pollsNumber := 5
+
+	//When
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var events []WatchEvent
+	for i := 0; i < pollsNumber; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			polled, err := watcher.Poll()
+			assert.Nil(t, err, "Watcher should not return an error")
+			mu.Lock()
+			events = append(events, polled...)
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	//Then
+	verifyWatcherEvents(t, events, first)
+}
+
+func TestWatcher_PollWithStatuses(t *testing.T) {
+	//Given
+	first, _ := readWatcherTestData(t)
+	movedConn := first.Content[0]
+	deletedConn := first.Content[1]
+	second := first
+	second.Content = first.Content[2:]
+	second.TotalCount = Int(len(second.Content))
+	testHc := &http.Client{}
+	registerWatcherResponders(testHc, first, second)
+	defer httpmock.DeactivateAndReset()
+	movedResp := movedConn
+	movedResp.Status = String(ConnectionStatusDeprovisioning)
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ecx/v3/l2/connections/%s", baseURL, StringValue(movedConn.UUID)),
+		httpmock.NewJsonResponderOrPanic(200, movedResp))
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ecx/v3/l2/connections/%s", baseURL, StringValue(deletedConn.UUID)),
+		httpmock.NewStringResponder(404, ""))
+	ecxClient := NewClient(context.Background(), baseURL, testHc)
+	ecxClient.SetPageSize(IntValue(first.PageSize))
+	watcher := NewWatcher(ecxClient).WithStatuses([]string{ConnectionStatusProvisioning, ConnectionStatusProvisioned})
+
+	//When
+	_, err := watcher.Poll()
+	assert.Nil(t, err, "Watcher should not return an error")
+	events, err := watcher.Poll()
+	assert.Nil(t, err, "Watcher should not return an error")
+
+	//Then
+	assert.Equal(t, []WatchEvent{
+		StatusChangedEvent{Connection: *mapGETToL2Connection(movedResp), From: ConnectionStatusProvisioning, To: ConnectionStatusDeprovisioning},
+		ConnectionDeletedEvent{Connection: *mapGETToL2Connection(deletedConn)},
+	}, events, "Events match")
+}
+
+func readWatcherTestData(t *testing.T) (api.L2BuyerConnectionsResponse, api.L2BuyerConnectionsResponse) {
+	first := api.L2BuyerConnectionsResponse{}
+	if err := readJSONData("./test-fixtures/ecx_l2connections_get_resp.json", &first); err != nil {
+		assert.Failf(t, "Cannot read test response due to %s", err.Error())
+	}
+	second := api.L2BuyerConnectionsResponse{}
+	if err := readJSONData("./test-fixtures/ecx_l2connections_get_resp.json", &second); err != nil {
+		assert.Failf(t, "Cannot read test response due to %s", err.Error())
+	}
+	second.Content[0].Status = String(ConnectionStatusProvisioned)
+	second.Content[0].ProviderStatus = String(ConnectionStatusPendingApproval)
+	second.Content[2].Name = String("renamed")
+	second.Content[2].Speed = Int(1)
+	second.Content[2].SpeedUnit = String(SpeedUnitGB)
+	second.Content[2].ActionDetails = []api.L2ConnectionActionDetail{
+		{ActionType: String("EQUINIX_EXECUTE_ACTION"), OperationID: String("CONFIRM_CONNECTION")},
+	}
+	second.Content[1] = api.L2ConnectionResponse{UUID: String("newConnUUID"), Status: String(ConnectionStatusProvisioning)}
+	return first, second
+}
+
+func registerWatcherResponders(testHc *http.Client, first api.L2BuyerConnectionsResponse, second api.L2BuyerConnectionsResponse) {
+	calls := 0
+	httpmock.ActivateNonDefault(testHc)
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ecx/v3/l2/buyer/connections", baseURL),
+		func(r *http.Request) (*http.Response, error) {
+			calls++
+			if calls == 1 {
+				return httpmock.NewJsonResponse(200, first)
+			}
+			return httpmock.NewJsonResponse(200, second)
+		},
+	)
+}
+
+func verifyWatcherEvents(t *testing.T, events []WatchEvent, first api.L2BuyerConnectionsResponse) {
+	changedUUID := StringValue(first.Content[0].UUID)
+	updatedUUID := StringValue(first.Content[2].UUID)
+	deletedUUID := StringValue(first.Content[1].UUID)
+	for _, event := range events {
+		switch e := event.(type) {
+		case StatusChangedEvent:
+			assert.Equal(t, changedUUID, e.ConnectionUUID(), "StatusChanged UUID matches")
+			assert.Equal(t, ConnectionStatusProvisioning, e.From, "StatusChanged From matches")
+			assert.Equal(t, ConnectionStatusProvisioned, e.To, "StatusChanged To matches")
+		case ProviderStatusChangedEvent:
+			assert.Equal(t, changedUUID, e.ConnectionUUID(), "ProviderStatusChanged UUID matches")
+			assert.Equal(t, ConnectionStatusNotAvailable, e.From, "ProviderStatusChanged From matches")
+			assert.Equal(t, ConnectionStatusPendingApproval, e.To, "ProviderStatusChanged To matches")
+		case NameChangedEvent:
+			assert.Equal(t, updatedUUID, e.ConnectionUUID(), "NameChanged UUID matches")
+			assert.Equal(t, "renamed", e.To, "NameChanged To matches")
+		case SpeedChangedEvent:
+			assert.Equal(t, updatedUUID, e.ConnectionUUID(), "SpeedChanged UUID matches")
+			assert.Equal(t, 50*Megabit, e.From, "SpeedChanged From matches")
+			assert.Equal(t, Gigabit, e.To, "SpeedChanged To matches")
+		case ActionRequiredEvent:
+			assert.Equal(t, updatedUUID, e.ConnectionUUID(), "ActionRequired UUID matches")
+			assert.Equal(t, "CONFIRM_CONNECTION", StringValue(e.Action.OperationID), "ActionRequired OperationID matches")
+		case ConnectionCreatedEvent:
+			assert.Equal(t, "newConnUUID", e.ConnectionUUID(), "Created UUID matches")
+		case ConnectionDeletedEvent:
+			assert.Equal(t, deletedUUID, e.ConnectionUUID(), "Deleted UUID matches")
+		}
+	}
+	assert.Equal(t, 7, len(events), "Number of events matches")
+}